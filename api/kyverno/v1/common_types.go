@@ -6,6 +6,7 @@ import (
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/pod-security-admission/api"
 )
 
@@ -51,7 +52,8 @@ type AnyAllConditions struct {
 }
 
 // ContextEntry adds variables and data sources to a rule Context. Either a
-// ConfigMap reference or a APILookup must be provided.
+// ConfigMap reference, an APICall, a ServiceCall, an ImageRegistry or a
+// Variable must be provided.
 type ContextEntry struct {
 	// Name is the variable name.
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
@@ -63,6 +65,10 @@ type ContextEntry struct {
 	// data retrieved is stored in the context.
 	APICall *APICall `json:"apiCall,omitempty" yaml:"apiCall,omitempty"`
 
+	// Service defines an HTTP(S) request to an arbitrary service. The JSON
+	// data retrieved is stored in the context.
+	Service *ServiceCall `json:"service,omitempty" yaml:"service,omitempty"`
+
 	// ImageRegistry defines requests to an OCI/Docker V2 registry to fetch image
 	// details.
 	ImageRegistry *ImageRegistry `json:"imageRegistry,omitempty" yaml:"imageRegistry,omitempty"`
@@ -109,18 +115,31 @@ type ConfigMapReference struct {
 
 	// Namespace is the ConfigMap namespace.
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Cache enables caching of the ConfigMap data across rule evaluations.
+	// +optional
+	Cache *CachePolicy `json:"cache,omitempty" yaml:"cache,omitempty"`
 }
 
 // APICall defines an HTTP request to the Kubernetes API server. The JSON
 // data retrieved is stored in the context. An APICall contains a URLPath
-// used to perform the HTTP GET request and an optional JMESPath used to
-// transform the retrieved JSON data.
+// used to perform the HTTP GET or POST request and an optional JMESPath used
+// to transform the retrieved JSON data.
 type APICall struct {
-	// URLPath is the URL path to be used in the HTTP GET request to the
+	// URLPath is the URL path to be used in the HTTP GET or POST request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
 	// The format required is the same format used by the `kubectl get --raw` command.
 	URLPath string `json:"urlPath" yaml:"urlPath"`
 
+	// Method is the HTTP request type (GET or POST). Defaults to GET.
+	// +optional
+	Method Method `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Data specifies the JSON body of a POST request. Variables are
+	// substituted in the values before the request is sent.
+	// +optional
+	Data []RequestData `json:"data,omitempty" yaml:"data,omitempty"`
+
 	// JMESPath is an optional JSON Match Expression that can be used to
 	// transform the JSON response returned from the API server. For example
 	// a JMESPath of "items | length(@)" applied to the API server response
@@ -128,6 +147,73 @@ type APICall struct {
 	// of deployments across all namespaces.
 	// +optional
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
+
+	// Cache enables caching of the API call results across rule evaluations.
+	// Results are cached by method, URL path, request data and JMESPath.
+	// +optional
+	Cache *CachePolicy `json:"cache,omitempty" yaml:"cache,omitempty"`
+}
+
+// RequestData contains the key and value of a field of the HTTP request body.
+type RequestData struct {
+	// Key is the field name in the request body.
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the field value, which can be any JSON value.
+	Value *apiextv1.JSON `json:"value" yaml:"value"`
+}
+
+// CachePolicy configures caching of the data retrieved by a context entry.
+// Cached data is invalidated when the referenced resources change, or when
+// the TTL expires, whichever comes first.
+type CachePolicy struct {
+	// TTL is the maximum duration the data is cached for. Defaults to 60s.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+// Method is the HTTP request method.
+// +kubebuilder:validation:Enum=GET;POST
+type Method string
+
+const (
+	// MethodGet performs an HTTP GET request.
+	MethodGet Method = "GET"
+	// MethodPost performs an HTTP POST request.
+	MethodPost Method = "POST"
+)
+
+// ServiceCall defines an HTTP(S) request to an arbitrary service. The JSON
+// data retrieved is stored in the context. A ServiceCall contains the URL
+// of the service, an optional request body built with JMESPath and an
+// optional JMESPath used to transform the retrieved JSON data.
+type ServiceCall struct {
+	// URL is the service URL (e.g. "https://cmdb.example.com/api/v1/owners").
+	URL string `json:"url" yaml:"url"`
+
+	// Method is the HTTP request method (GET or POST). Defaults to GET.
+	// +optional
+	Method Method `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Body is an optional JMESPath expression evaluated against the rule
+	// context. The result is sent as the JSON request body, for example
+	// "{namespace: request.namespace, owner: request.object.metadata.labels.owner}".
+	// +optional
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// CABundle is an optional PEM encoded CA bundle used to validate the
+	// service certificate. If not provided, the system roots are used.
+	// +optional
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// Timeout is the maximum duration of the request. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the JSON response returned from the service.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
 // Condition defines variable-based conditional criteria for rule execution.
@@ -137,8 +223,9 @@ type Condition struct {
 
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
-	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// GreaterThan, LessThanOrEquals, LessThan, Range, NotRange, RegexMatches, AnyRegexMatches,
+	// AllRegexMatches, DurationGreaterThanOrEquals, DurationGreaterThan, DurationLessThanOrEquals,
+	// DurationLessThan
 	Operator ConditionOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -164,7 +251,7 @@ func (c *Condition) SetValue(in apiextensions.JSON) {
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;Range;NotRange;RegexMatches;AnyRegexMatches;AllRegexMatches;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -183,6 +270,12 @@ type ConditionOperator string
 // "GreaterThan" evaluates if the key (numeric) is greater than the value (numeric).
 // "LessThanOrEquals" evaluates if the key (numeric) is less than or equal to the value (numeric).
 // "LessThan" evaluates if the key (numeric) is less than the value (numeric).
+// "Range" evaluates if the key (numeric, quantity, duration or semver) is within the inclusive range
+// given by the value, formatted as "min-max" or, for semver, as a semver range expression.
+// "NotRange" evaluates if the key (numeric, quantity, duration or semver) is outside of the range given by the value.
+// "RegexMatches" evaluates if the key matches the regular expression, or any of the regular expressions, in the value.
+// "AnyRegexMatches" evaluates if any of the keys match the regular expression, or any of the regular expressions, in the value.
+// "AllRegexMatches" evaluates if all the keys match the regular expression, or any of the regular expressions, in the value.
 // "DurationGreaterThanOrEquals" evaluates if the key (duration) is greater than or equal to the value (duration)
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
@@ -202,6 +295,11 @@ var ConditionOperators = map[string]ConditionOperator{
 	"GreaterThan":                 ConditionOperator("GreaterThan"),
 	"LessThanOrEquals":            ConditionOperator("LessThanOrEquals"),
 	"LessThan":                    ConditionOperator("LessThan"),
+	"Range":                       ConditionOperator("Range"),
+	"NotRange":                    ConditionOperator("NotRange"),
+	"RegexMatches":                ConditionOperator("RegexMatches"),
+	"AnyRegexMatches":             ConditionOperator("AnyRegexMatches"),
+	"AllRegexMatches":             ConditionOperator("AllRegexMatches"),
 	"DurationGreaterThanOrEquals": ConditionOperator("DurationGreaterThanOrEquals"),
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
@@ -492,6 +590,17 @@ type Generation struct {
 	// resource will be created with default data only.
 	// +optional
 	Clone CloneFrom `json:"clone,omitempty" yaml:"clone,omitempty"`
+
+	// CloneList specifies the list of source resources used to populate each generated resource.
+	// Each matching source resource is cloned with its own kind and name into the namespace of
+	// the generated resource. At most one of Data, Clone or CloneList can be specified.
+	// +optional
+	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
+
+	// ForEach applies generate rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
+	// Each element generates its own resource, which is deleted when the element is removed if Synchronize is set.
+	// +optional
+	ForEachGeneration []ForEachGeneration `json:"foreach,omitempty" yaml:"foreach,omitempty"`
 }
 
 func (g *Generation) GetData() apiextensions.JSON {
@@ -502,6 +611,65 @@ func (g *Generation) SetData(in apiextensions.JSON) {
 	g.RawData = ToJSON(in)
 }
 
+// ForEachGeneration applies generate rules to each element of a list.
+type ForEachGeneration struct {
+	// List specifies a JMESPath expression that results in one or more elements
+	// for which a resource is generated.
+	List string `json:"list,omitempty" yaml:"list,omitempty"`
+
+	// Context defines variables and data sources that can be used during rule execution.
+	// +optional
+	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
+	// AnyAllConditions are used to determine if a policy rule should be applied by evaluating a
+	// set of conditions. The declaration can contain nested `any` or `all` statements.
+	// See: https://kyverno.io/docs/writing-policies/preconditions/
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	AnyAllConditions *AnyAllConditions `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+
+	// ResourceSpec contains information to select the resource generated for the element.
+	ResourceSpec `json:",omitempty" yaml:",omitempty"`
+
+	// Data provides the resource declaration used to populate the resource generated for the element.
+	// At most one of Data or Clone can be specified.
+	// +optional
+	RawData *apiextv1.JSON `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// Clone specifies the source resource used to populate the resource generated for the element.
+	// At most one of Data or Clone can be specified.
+	// +optional
+	Clone CloneFrom `json:"clone,omitempty" yaml:"clone,omitempty"`
+}
+
+func (g *ForEachGeneration) GetData() apiextensions.JSON {
+	return FromJSON(g.RawData)
+}
+
+func (g *ForEachGeneration) SetData(in apiextensions.JSON) {
+	g.RawData = ToJSON(in)
+}
+
+// CloneList provides the list of source resources used to generate target resources.
+type CloneList struct {
+	// Namespace specifies source resource namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Kinds is a list of resource kinds.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+
+	// Selector is a label selector used to select the source resources.
+	// Wildcard characters are not supported.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+// IsEmpty checks if the clone list is not specified
+func (c *CloneList) IsEmpty() bool {
+	return len(c.Kinds) == 0
+}
+
 // CloneFrom provides the location of the source resource used to generate target resources.
 // The resource kind is derived from the match criteria.
 type CloneFrom struct {
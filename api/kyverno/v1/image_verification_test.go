@@ -10,6 +10,7 @@ import (
 
 func Test_ImageVerification(t *testing.T) {
 	path := field.NewPath("dummy")
+	two := 2
 	testCases := []struct {
 		name    string
 		subject ImageVerification
@@ -136,6 +137,76 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multiple attestor entries",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Count: &two, Entries: []Attestor{
+						{Keys: &StaticKeyAttestor{PublicKeys: "bla"}},
+						{Keys: &StaticKeyAttestor{PublicKeys: "bla"}},
+						{Keyless: &KeylessAttestor{Rekor: &CTLog{URL: "https://rekor.sigstore.dev"}, Issuer: "bla", Subject: "bla"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "count exceeds attestor entries",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Count: &two, Entries: []Attestor{
+						{Keys: &StaticKeyAttestor{PublicKeys: "bla"}},
+					}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0), &i.Attestors[0], "Count cannot exceed length of entries"),
+				}
+			},
+		},
+		{
+			name: "count applied across static keys",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Count: &two, Entries: []Attestor{
+						{Keys: &StaticKeyAttestor{PublicKeys: "-----END PUBLIC KEY-----\n-----END PUBLIC KEY-----"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "valid notary v2 certificates attestor",
+			subject: ImageVerification{
+				Type:            NotaryV2,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Certificates: &CertificateAttestor{CertificateChain: "bla"},
+					}}},
+				},
+			},
+		},
+		{
+			name: "invalid notary v2 static key attestor",
+			subject: ImageVerification{
+				Type:            NotaryV2,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{PublicKeys: "bla"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0),
+						i.Attestors[0].Entries[0], "Only certificates are supported for NotaryV2"),
+				}
+			},
+		},
 		{
 			name: "invalid keyless attestor",
 			subject: ImageVerification{
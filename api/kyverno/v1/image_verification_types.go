@@ -2,16 +2,31 @@ package v1
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// ImageVerificationType selects the type of verifier to use
+// +kubebuilder:validation:Enum=Cosign;NotaryV2
+type ImageVerificationType string
+
+const (
+	Cosign   ImageVerificationType = "Cosign"
+	NotaryV2 ImageVerificationType = "NotaryV2"
+)
+
 // ImageVerification validates that images that match the specified pattern
 // are signed with the supplied public key. Once the image is verified it is
 // mutated to include the SHA digest retrieved during the registration.
 type ImageVerification struct {
+	// Type specifies the method of signature validation. The allowed options
+	// are Cosign and NotaryV2. By default Cosign is used if a type is not specified.
+	// +kubebuilder:validation:Optional
+	Type ImageVerificationType `json:"type,omitempty" yaml:"type,omitempty"`
+
 	// Image is the image name consisting of the registry address, repository, image, and tag.
 	// Wildcards ('*' and '?') are allowed. See: https://kubernetes.io/docs/concepts/containers/images.
 	// Deprecated. Use ImageReferences instead.
@@ -221,6 +236,41 @@ func (iv *ImageVerification) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, attestorErrors...)
 	}
 
+	if copy.Type == NotaryV2 {
+		errs = append(errs, copy.validateNotaryV2(path)...)
+	}
+
+	return errs
+}
+
+func (iv *ImageVerification) validateNotaryV2(path *field.Path) (errs field.ErrorList) {
+	if len(iv.Attestations) > 0 {
+		errs = append(errs, field.Invalid(path.Child("attestations"), iv.Attestations, "Attestations are not supported for NotaryV2"))
+	}
+
+	attestorsPath := path.Child("attestors")
+	for i, as := range iv.Attestors {
+		errs = append(errs, validateNotaryV2AttestorSet(&as, attestorsPath.Index(i))...)
+	}
+
+	return errs
+}
+
+func validateNotaryV2AttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorList) {
+	entriesPath := path.Child("entries")
+	for i, e := range as.Entries {
+		entryPath := entriesPath.Index(i)
+		if e.Keys != nil || e.Keyless != nil {
+			errs = append(errs, field.Invalid(entryPath, e, "Only certificates are supported for NotaryV2"))
+		}
+
+		if e.Attestor != nil {
+			if nestedAttestorSet, err := AttestorSetUnmarshal(e.Attestor); err == nil {
+				errs = append(errs, validateNotaryV2AttestorSet(nestedAttestorSet, entryPath.Child("attestor"))...)
+			}
+		}
+	}
+
 	return errs
 }
 
@@ -230,15 +280,13 @@ func (as *AttestorSet) Validate(path *field.Path) (errs field.ErrorList) {
 
 func validateAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorList) {
 	if as.Count != nil {
-		if *as.Count > len(as.Entries) {
+		if *as.Count > countAttestors(as) {
 			errs = append(errs, field.Invalid(path, as, "Count cannot exceed length of entries"))
 		}
 	}
 
 	if len(as.Entries) == 0 {
 		errs = append(errs, field.Invalid(path, as, "An entry is required"))
-	} else if len(as.Entries) > 1 {
-		errs = append(errs, field.Invalid(path, as, "Only one entry is currently supported"))
 	}
 
 	entriesPath := path.Child("entries")
@@ -250,6 +298,24 @@ func validateAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorLis
 	return errs
 }
 
+// countAttestors returns the number of attestors the count is applied to. Each
+// public key in a static key entry is processed as a separate attestor.
+func countAttestors(as *AttestorSet) int {
+	count := 0
+	for _, e := range as.Entries {
+		if e.Keys != nil {
+			if keys := strings.Count(e.Keys.PublicKeys, "-----END PUBLIC KEY-----"); keys > 1 {
+				count += keys
+				continue
+			}
+		}
+
+		count++
+	}
+
+	return count
+}
+
 func (a *Attestor) Validate(path *field.Path) (errs field.ErrorList) {
 	if (a.Keys != nil && (a.Certificates != nil || a.Keyless != nil || a.Attestor != nil)) ||
 		(a.Certificates != nil && (a.Keys != nil || a.Keyless != nil || a.Attestor != nil)) ||
@@ -26,7 +26,7 @@ func Test_ResourceDescription(t *testing.T) {
 			Names: []string{"bar", "baz"},
 		},
 		errors: []string{
-			`dummy: Invalid value: v1.ResourceDescription{Kinds:[]string(nil), Name:"foo", Names:[]string{"bar", "baz"}, Namespaces:[]string(nil), Annotations:map[string]string(nil), Selector:(*v1.LabelSelector)(nil), NamespaceSelector:(*v1.LabelSelector)(nil)}: Both name and names can not be specified together`,
+			`dummy: Invalid value: v1.ResourceDescription{Kinds:[]string(nil), Name:"foo", Names:[]string{"bar", "baz"}, Namespaces:[]string(nil), Annotations:map[string]string(nil), Selector:(*v1.LabelSelector)(nil), NamespaceSelector:(*v1.LabelSelector)(nil), Operations:[]v1.AdmissionOperation(nil)}: Both name and names can not be specified together`,
 		},
 	}, {
 		name:       "selector",
@@ -57,6 +57,21 @@ func Test_ResourceDescription(t *testing.T) {
 		errors: []string{
 			"dummy.namespaces: Forbidden: Filtering namespaces not allowed in namespaced policies",
 		},
+	}, {
+		name:       "operations",
+		namespaced: true,
+		subject: ResourceDescription{
+			Operations: []AdmissionOperation{Create, Update},
+		},
+	}, {
+		name:       "bad-operations",
+		namespaced: true,
+		subject: ResourceDescription{
+			Operations: []AdmissionOperation{Delete, "PATCH"},
+		},
+		errors: []string{
+			`dummy.operations[1]: Unsupported value: "PATCH": supported values: "CREATE", "UPDATE", "DELETE", "CONNECT"`,
+		},
 	}}
 
 	path := field.NewPath("dummy")
@@ -4,11 +4,23 @@ import (
 	"fmt"
 
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// AdmissionOperation is an admission operation, one of CREATE, UPDATE, DELETE or CONNECT.
+// +kubebuilder:validation:Enum=CREATE;UPDATE;DELETE;CONNECT
+type AdmissionOperation admissionv1.Operation
+
+const (
+	Create  AdmissionOperation = AdmissionOperation(admissionv1.Create)
+	Update  AdmissionOperation = AdmissionOperation(admissionv1.Update)
+	Delete  AdmissionOperation = AdmissionOperation(admissionv1.Delete)
+	Connect AdmissionOperation = AdmissionOperation(admissionv1.Connect)
+)
+
 // ResourceDescription contains criteria used to match resources.
 type ResourceDescription struct {
 	// Kinds is a list of resource kinds.
@@ -51,6 +63,12 @@ type ResourceDescription struct {
 	// does not match an empty label set.
 	// +optional
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
+	// Operations is a list of admission operations, one of CREATE, UPDATE, DELETE or CONNECT.
+	// When empty, all the operations are matched. Resources processed outside of an admission
+	// request, like in background scans, are matched as CREATE operations.
+	// +optional
+	Operations []AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -68,6 +86,14 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 			}
 		}
 	}
+	operationsChild := path.Child("operations")
+	for i, operation := range r.Operations {
+		switch operation {
+		case Create, Update, Delete, Connect:
+		default:
+			errs = append(errs, field.NotSupported(operationsChild.Index(i), operation, []string{string(Create), string(Update), string(Delete), string(Connect)}))
+		}
+	}
 	if namespaced {
 		if len(r.Namespaces) > 0 {
 			errs = append(errs, field.Forbidden(path.Child("namespaces"), "Filtering namespaces not allowed in namespaced policies"))
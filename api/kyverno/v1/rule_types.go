@@ -139,13 +139,13 @@ func (r *Rule) IsMutateExisting() bool {
 	return r.Mutation.Targets != nil
 }
 
-// IsCloneSyncGenerate checks if the generate rule has the clone block with sync=true
+// IsCloneSyncGenerate checks if the generate rule has the clone or the cloneList block with sync=true
 func (r *Rule) GetCloneSyncForGenerate() (clone bool, sync bool) {
 	if !r.HasGenerate() {
 		return
 	}
 
-	if r.Generation.Clone.Name != "" {
+	if r.Generation.Clone.Name != "" || !r.Generation.CloneList.IsEmpty() {
 		clone = true
 	}
 
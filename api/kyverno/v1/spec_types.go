@@ -72,6 +72,12 @@ type Spec struct {
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
 	WebhookTimeoutSeconds *int32 `json:"webhookTimeoutSeconds,omitempty" yaml:"webhookTimeoutSeconds,omitempty"`
 
+	// UseDedicatedWebhook registers the policy in its own resource webhooks, with the failure policy and timeout
+	// of the policy, instead of the webhooks shared with the other policies.
+	// Defaults to "false" if not specified.
+	// +optional
+	UseDedicatedWebhook bool `json:"useDedicatedWebhook,omitempty" yaml:"useDedicatedWebhook,omitempty"`
+
 	// MutateExistingOnPolicyUpdate controls if a mutateExisting policy is applied on policy events.
 	// Default value is "false".
 	// +optional
@@ -2,8 +2,10 @@ package apply
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	"github.com/kyverno/kyverno/pkg/policyreport"
@@ -51,9 +54,22 @@ To apply on a resource:
 To apply on a folder of resources:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/
 
+To apply on a kustomization or a Helm chart, rendered locally:
+        kyverno apply /path/to/policy.yaml --kustomize=/path/to/overlays/prod
+        kyverno apply /path/to/policy.yaml --helm-chart=/path/to/chart --values=/path/to/values.yaml --helm-namespace=prod
+
 To apply on a cluster:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster
 
+To audit all the resources of a cluster with 8 workers and write the results to a ClusterPolicyReport:
+        kyverno apply /path/to/folderOfPolicies --cluster --audit --workers=8 --report-file=/path/to/report.yaml
+
+To print the results as SARIF, for code scanning tools to annotate the resource files:
+        kyverno apply /path/to/policy.yaml --resource=/path/to/resources/ --output-format=sarif > results.sarif
+
+To apply again each time a policy or resource file changes:
+        kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/ --watch
+
 
 To apply policy with variables:
 
@@ -105,29 +121,102 @@ To apply policy with variables:
 			labels:
 				<label key>: <label value>
 
+Exit codes:
+	0: no policy rule failed
+	1: a policy rule failed on a resource, or the command line is invalid
+	2: the policies could not be applied, or a policy rule returned an error
+
 More info: https://kyverno.io/docs/kyverno-cli/
 `
 
 func Command() *cobra.Command {
 	var cmd *cobra.Command
-	var resourcePaths []string
-	var cluster, policyReport, stdin, registryAccess bool
-	var mutateLogPath, variablesString, valuesFile, namespace, userInfoPath string
+	var resourcePaths, kustomizeDirs, helmCharts, helmValues []string
+	var cluster, policyReport, stdin, registryAccess, watchFiles, audit, includeCRDs bool
+	var mutateLogPath, variablesString, valuesFile, namespace, helmNamespace, userInfoPath, reportFile, outputFormat string
+	var workers int
+	var pageSize int64
 	cmd = &cobra.Command{
 		Use:     "apply",
 		Short:   "applies policies on resources",
 		Example: applyHelp,
 		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			// once the flags are validated, errors exit with exitErrors to be told apart from violations
+			var applying bool
 			defer func() {
 				if err != nil {
 					if !sanitizederror.IsErrorSanitized(err) {
 						log.Log.Error(err, "failed to sanitize")
 						err = fmt.Errorf("internal error")
 					}
+					if applying {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(exitErrors)
+					}
 				}
 			}()
 
-			rc, resources, skipInvalidPolicies, pvInfos, err := applyCommandHelper(resourcePaths, userInfoPath, cluster, policyReport, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, stdin, registryAccess)
+			if err := validateOutputFormat(outputFormat); err != nil {
+				return sanitizederror.New(err.Error())
+			}
+			if outputFormat != textOutput && (policyReport || stdin || watchFiles || audit) {
+				return sanitizederror.New("--output-format cannot be used with --policy-report, --stdin, --watch or --audit")
+			}
+
+			if audit {
+				if !cluster {
+					return sanitizederror.New("--audit requires --cluster")
+				}
+				if watchFiles || stdin || len(resourcePaths) > 0 || variablesString != "" || valuesFile != "" || userInfoPath != "" || mutateLogPath != "" {
+					return sanitizederror.New("--audit cannot be used with --watch, --stdin, --resource, --set, --values-file, --userinfo or --output")
+				}
+				if workers < 1 {
+					return sanitizederror.New("--workers must be at least 1")
+				}
+				if pageSize < 0 {
+					return sanitizederror.New("--page-size cannot be negative")
+				}
+				applying = true
+				rc, err := applyAudit(policyPaths, namespace, policyReport, reportFile, workers, pageSize)
+				if err != nil {
+					return err
+				}
+				if code := exitCode(rc); code != 0 {
+					os.Exit(code)
+				}
+				return nil
+			}
+
+			if reportFile != "" {
+				return sanitizederror.New("--report-file can only be used with --audit")
+			}
+
+			renderOpts := common.RenderOptions{KustomizeDirs: kustomizeDirs, HelmCharts: helmCharts, HelmValues: helmValues, HelmNamespace: helmNamespace, IncludeCRDs: includeCRDs}
+			if (len(helmValues) > 0 || helmNamespace != "" || includeCRDs) && len(helmCharts) == 0 {
+				return sanitizederror.New("--values, --helm-namespace and --include-crds require --helm-chart")
+			}
+			if !renderOpts.IsEmpty() && cluster {
+				return sanitizederror.New("--kustomize and --helm-chart cannot be used with --cluster")
+			}
+
+			if watchFiles {
+				if cluster || stdin || (len(policyPaths) > 0 && policyPaths[0] == "-") || (len(resourcePaths) > 0 && resourcePaths[0] == "-") {
+					return sanitizederror.New("--watch can only be used with policy and resource files")
+				}
+				return watchApply(resourcePaths, renderOpts, userInfoPath, policyReport, mutateLogPath, variablesString, valuesFile, policyPaths, registryAccess)
+			}
+
+			applying = true
+			if outputFormat != textOutput {
+				return applyWithOutputFormat(outputFormat, resourcePaths, renderOpts, userInfoPath, cluster, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, registryAccess)
+			}
+
+			rendered, err := renderResources(renderOpts)
+			if err != nil {
+				return err
+			}
+
+			rc, resources, skipInvalidPolicies, pvInfos, _, err := applyCommandHelper(os.Stdout, resourcePaths, rendered, userInfoPath, cluster, policyReport, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, stdin, registryAccess)
 			if err != nil {
 				return err
 			}
@@ -137,8 +226,14 @@ func Command() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringArrayVarP(&resourcePaths, "resource", "r", []string{}, "Path to resource files")
+	cmd.Flags().StringArrayVarP(&kustomizeDirs, "kustomize", "", []string{}, "Path to kustomization directories rendered as resources")
+	cmd.Flags().StringArrayVarP(&helmCharts, "helm-chart", "", []string{}, "Path to Helm chart directories or archives rendered as resources")
+	cmd.Flags().StringArrayVarP(&helmValues, "values", "", []string{}, "Values files of the Helm charts, not to be confused with --values-file")
+	cmd.Flags().StringVarP(&helmNamespace, "helm-namespace", "", "", "Namespace of the Helm releases, default if empty")
+	cmd.Flags().BoolVarP(&includeCRDs, "include-crds", "", false, "Renders the CRDs of the Helm charts as resources too")
 	cmd.Flags().BoolVarP(&cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
 	cmd.Flags().StringVarP(&mutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "", textOutput, "Output format of the results, one of text, json, yaml, sarif or junit. Other formats than text print the progress messages to stderr")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&userInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
 	cmd.Flags().StringVarP(&variablesString, "set", "s", "", "Variables that are required")
@@ -147,74 +242,87 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Optional Policy parameter passed with cluster flag")
 	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "Optional mutate policy parameter to pipe directly through to kubectl")
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
+	cmd.Flags().BoolVarP(&audit, "audit", "", false, "Audits all the cluster resources matched by the policies in parallel, requires --cluster")
+	cmd.Flags().IntVarP(&workers, "workers", "", runtime.NumCPU(), "Number of resources audited in parallel with --audit")
+	cmd.Flags().Int64VarP(&pageSize, "page-size", "", defaultAuditPageSize, "Number of resources listed per request to the cluster with --audit, 0 disables pagination")
+	cmd.Flags().StringVarP(&reportFile, "report-file", "", "", "Writes the ClusterPolicyReport of the --audit results to a file")
+	cmd.Flags().BoolVarP(&watchFiles, "watch", "w", false, "Apply the policies again when the policy, resource, values or user info files change")
 	return cmd
 }
 
-func applyCommandHelper(resourcePaths []string, userInfoPath string, cluster bool, policyReport bool, mutateLogPath string,
+// renderResources renders the kustomizations and Helm charts as resources
+func renderResources(renderOpts common.RenderOptions) ([]common.RenderedResource, error) {
+	rendered, err := common.GetRenderedResources(renderOpts)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to render resources", err)
+	}
+	return rendered, nil
+}
+
+func applyCommandHelper(out io.Writer, resourcePaths []string, rendered []common.RenderedResource, userInfoPath string, cluster bool, policyReport bool, mutateLogPath string,
 	variablesString string, valuesFile string, namespace string, policyPaths []string, stdin bool, registryAccess bool,
-) (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []policyreport.Info, err error) {
+) (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []policyreport.Info, responses []*response.EngineResponse, err error) {
 	store.SetMock(true)
 	store.SetRegistryAccess(registryAccess)
 	kubernetesConfig := genericclioptions.NewConfigFlags(true)
 	fs := memfs.New()
 
 	if valuesFile != "" && variablesString != "" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
 	}
 
 	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(variablesString, valuesFile, fs, false, "")
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to decode yaml", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to decode yaml", err)
 		}
-		return rc, resources, skipInvalidPolicies, pvInfos, err
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 	}
 
 	openAPIController, err := openapi.NewOpenAPIController()
 	if err != nil {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to initialize openAPIController", err)
 	}
 
 	var dClient dclient.Interface
 	if cluster {
 		restConfig, err := kubernetesConfig.ToRESTConfig()
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 		kubeClient, err := kubernetes.NewForConfig(restConfig)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 		dClient, err = dclient.NewClient(restConfig, kubeClient, nil, 15*time.Minute, make(chan struct{}))
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 	}
 
 	if len(policyPaths) == 0 {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("require policy", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("require policy", err)
 	}
 
 	if (len(policyPaths) > 0 && policyPaths[0] == "-") && len(resourcePaths) > 0 && resourcePaths[0] == "-" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("a stdin pipe can be used for either policies or resources, not both", err)
 	}
 
 	policies, err := common.GetPoliciesFromPaths(fs, policyPaths, false, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
-		os.Exit(1)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to load policies", err)
 	}
 
-	if len(resourcePaths) == 0 && !cluster {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("resource file(s) or cluster required", err)
+	if len(resourcePaths) == 0 && !cluster && len(rendered) == 0 {
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("resource file(s), kustomization, Helm chart or cluster required", err)
 	}
 
 	mutateLogPathIsDir, err := checkMutateLogPath(mutateLogPath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to create file/folder", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to create file/folder", err)
 		}
-		return rc, resources, skipInvalidPolicies, pvInfos, err
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 	}
 
 	// empty the previous contents of the file just in case if the file already existed before with some content(so as to perform overwrites)
@@ -225,32 +333,35 @@ func applyCommandHelper(resourcePaths []string, userInfoPath string, cluster boo
 		_, err := os.OpenFile(mutateLogPath, os.O_TRUNC|os.O_WRONLY, 0o600) // #nosec G304
 		if err != nil {
 			if !sanitizederror.IsErrorSanitized(err) {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to truncate the existing file at "+mutateLogPath, err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to truncate the existing file at "+mutateLogPath, err)
 			}
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, err
 		}
 	}
 
 	mutatedPolicies, err := common.MutatePolicies(policies)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
-			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to mutate policy", err)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to mutate policy", err)
 		}
 	}
 
 	err = common.PrintMutatedPolicy(mutatedPolicies)
 	if err != nil {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to marsal mutated policy", err)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to marsal mutated policy", err)
 	}
 
 	resources, err = common.GetResourceAccordingToResourcePath(fs, resourcePaths, cluster, mutatedPolicies, dClient, namespace, policyReport, false, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		os.Exit(1)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to load resources", err)
+	}
+
+	for _, r := range rendered {
+		resources = append(resources, r.Resource)
 	}
 
 	if (len(resources) > 1 || len(mutatedPolicies) > 1) && variablesString != "" {
-		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
+		return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
 	}
 
 	// get the user info as request info from a different file
@@ -259,8 +370,7 @@ func applyCommandHelper(resourcePaths []string, userInfoPath string, cluster boo
 	if userInfoPath != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoPath, false, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
-			os.Exit(1)
+			return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError("failed to load request info", err)
 		}
 		store.SetSubjects(subjectInfo)
 	}
@@ -295,9 +405,9 @@ func applyCommandHelper(resourcePaths []string, userInfoPath string, cluster boo
 	if len(mutatedPolicies) > 0 && len(resources) > 0 {
 		if !stdin {
 			if mutatedPolicyRulesCount > policyRulesCount {
-				fmt.Printf("\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			} else {
-				fmt.Printf("\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			}
 		}
 	}
@@ -336,18 +446,19 @@ func applyCommandHelper(resourcePaths []string, userInfoPath string, cluster boo
 		for _, resource := range resources {
 			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
 
-			_, info, err := common.ApplyPolicyOnResource(policy, resource, mutateLogPath, mutateLogPathIsDir, thisPolicyResourceValues, userInfo, policyReport, namespaceSelectorMap, stdin, rc, true, nil)
+			ers, info, err := common.ApplyPolicyOnResource(policy, resource, mutateLogPath, mutateLogPathIsDir, thisPolicyResourceValues, userInfo, policyReport, namespaceSelectorMap, stdin, rc, true, nil, out, nil)
 			if err != nil {
-				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
+				return rc, resources, skipInvalidPolicies, pvInfos, responses, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
 			}
 			pvInfos = append(pvInfos, info)
+			responses = append(responses, ers...)
 		}
 	}
 
-	return rc, resources, skipInvalidPolicies, pvInfos, nil
+	return rc, resources, skipInvalidPolicies, pvInfos, responses, nil
 }
 
 // checkMutateLogPath - checking path for printing mutated resource (-o flag)
@@ -374,6 +485,14 @@ func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err erro
 
 // PrintReportOrViolation - printing policy report/violations
 func PrintReportOrViolation(policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []policyreport.Info) {
+	printReportOrViolation(policyReport, rc, resourcesLen, skipInvalidPolicies, stdin, pvInfos)
+
+	if code := exitCode(rc); code != 0 {
+		os.Exit(code)
+	}
+}
+
+func printReportOrViolation(policyReport bool, rc *common.ResultCounts, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []policyreport.Info) {
 	divider := "----------------------------------------------------------------------"
 
 	if len(skipInvalidPolicies.skipped) > 0 {
@@ -412,10 +531,6 @@ func PrintReportOrViolation(policyReport bool, rc *common.ResultCounts, resource
 				rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 		}
 	}
-
-	if rc.Fail > 0 || rc.Error > 0 {
-		os.Exit(1)
-	}
 }
 
 // createFileOrFolder - creating file or folder according to path provided
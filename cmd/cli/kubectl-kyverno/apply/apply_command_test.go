@@ -1,6 +1,7 @@
 package apply
 
 import (
+	"io/ioutil"
 	"testing"
 
 	preport "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
@@ -71,7 +72,7 @@ func Test_Apply(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		_, _, _, info, _ := applyCommandHelper(tc.ResourcePaths, "", false, true, "", "", "", "", tc.PolicyPaths, false, false)
+		_, _, _, info, _, _ := applyCommandHelper(ioutil.Discard, tc.ResourcePaths, nil, "", false, true, "", "", "", "", tc.PolicyPaths, false, false)
 		resps := buildPolicyReports(info)
 		for i, resp := range resps {
 			compareSummary(tc.expectedPolicyReports[i].Summary, resp.UnstructuredContent()["summary"].(map[string]interface{}))
@@ -0,0 +1,462 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineContext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policy2 "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	yaml1 "sigs.k8s.io/yaml"
+)
+
+const (
+	defaultAuditPageSize = 500
+	auditProgressPeriod  = time.Second
+)
+
+// auditOptions configures the audit of the cluster resources
+type auditOptions struct {
+	// namespace limits the audit to the resources of a namespace.
+	namespace string
+	// workers is the number of resources audited in parallel.
+	workers int
+	// pageSize is the number of resources listed by each request to the cluster.
+	pageSize int64
+	// violations receives the violations as they are found, nil discards them.
+	violations io.Writer
+	// progress receives the progress of the audit, nil discards it.
+	progress io.Writer
+}
+
+// applyAudit applies the validate and verifyImages rules of the policies to all the matching resources of the cluster,
+// it prints the violations as they are found and writes the results to a single ClusterPolicyReport
+func applyAudit(policyPaths []string, namespace string, policyReport bool, reportFile string, workers int, pageSize int64) (*common.ResultCounts, error) {
+	store.SetMock(false)
+	fs := memfs.New()
+
+	if len(policyPaths) == 0 {
+		return nil, sanitizederror.New("require policy")
+	}
+
+	openAPIController, err := openapi.NewOpenAPIController()
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+	}
+
+	restConfig, err := genericclioptions.NewConfigFlags(true).ToRESTConfig()
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to load the cluster configuration", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to create the cluster client", err)
+	}
+
+	dClient, err := dclient.NewClient(restConfig, kubeClient, nil, 15*time.Minute, make(chan struct{}))
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to create the cluster client", err)
+	}
+
+	policies, err := common.GetPoliciesFromPaths(fs, policyPaths, false, "")
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to load policies", err)
+	}
+
+	mutatedPolicies, err := common.MutatePolicies(policies)
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return nil, sanitizederror.NewWithError("failed to mutate policy", err)
+		}
+	}
+
+	var validPolicies []kyvernov1.PolicyInterface
+	for _, policy := range mutatedPolicies {
+		if _, err := policy2.Validate(policy, nil, true, openAPIController); err != nil {
+			log.Log.Error(err, "policy validation error")
+			fmt.Fprintf(os.Stderr, "skipping invalid policy %s: %v\n", policy.GetName(), err)
+			continue
+		}
+		validPolicies = append(validPolicies, policy)
+	}
+
+	opts := auditOptions{
+		namespace: namespace,
+		workers:   workers,
+		pageSize:  pageSize,
+		progress:  os.Stderr,
+	}
+	if !policyReport {
+		opts.violations = os.Stdout
+	}
+
+	rc, report, err := auditCluster(context.Background(), dClient, validPolicies, opts)
+	if err != nil {
+		return rc, sanitizederror.NewWithError("failed to audit the cluster resources", err)
+	}
+	if reportFile != "" {
+		if err := writeReport(report, reportFile); err != nil {
+			return rc, sanitizederror.NewWithError("failed to write the policy report to "+reportFile, err)
+		}
+	}
+
+	if policyReport {
+		divider := "----------------------------------------------------------------------"
+		fmt.Println(divider)
+		fmt.Println("POLICY REPORT:")
+		fmt.Println(divider)
+		yamlReport, _ := yaml1.Marshal(report)
+		fmt.Println(string(yamlReport))
+	} else {
+		fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
+			rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
+	}
+
+	return rc, nil
+}
+
+// auditPolicy is a policy audited on the cluster resources
+type auditPolicy struct {
+	policy       kyvernov1.PolicyInterface
+	validate     bool
+	verifyImages bool
+	scored       bool
+	category     string
+	severity     policyreportv1alpha2.PolicySeverity
+}
+
+func newAuditPolicy(policy kyvernov1.PolicyInterface) auditPolicy {
+	p := auditPolicy{policy: policy}
+	for _, rule := range autogen.ComputeRules(policy) {
+		if rule.HasValidate() || rule.HasImagesValidationChecks() {
+			p.validate = true
+		}
+		if rule.HasVerifyImages() {
+			p.verifyImages = true
+		}
+	}
+
+	p.scored = common.IsScored(policy)
+	annotations := policy.GetAnnotations()
+	p.category = annotations["policies.kyverno.io/category"]
+	switch severity := policyreportv1alpha2.PolicySeverity(annotations["policies.kyverno.io/severity"]); severity {
+	case policyreportv1alpha2.SeverityHigh, policyreportv1alpha2.SeverityMedium, policyreportv1alpha2.SeverityLow:
+		p.severity = severity
+	}
+	return p
+}
+
+// auditor applies the validate and verifyImages rules of policies to the cluster resources, like the background scan does
+type auditor struct {
+	client   dclient.Interface
+	policies []auditPolicy
+
+	lock            sync.Mutex
+	namespaceLabels map[string]map[string]string
+}
+
+func newAuditor(client dclient.Interface, policies []kyvernov1.PolicyInterface) *auditor {
+	a := &auditor{client: client, namespaceLabels: map[string]map[string]string{}}
+	for _, policy := range policies {
+		p := newAuditPolicy(policy)
+		if p.validate || p.verifyImages {
+			a.policies = append(a.policies, p)
+		}
+	}
+	return a
+}
+
+// auditCluster lists the resources matched by the policies page by page and audits them with the workers,
+// the violations are streamed as they are found and the results are returned as a single ClusterPolicyReport.
+// The audit stops with an error when the resources of a kind can not be listed.
+func auditCluster(ctx context.Context, client dclient.Interface, policies []kyvernov1.PolicyInterface, opts auditOptions) (*common.ResultCounts, *policyreportv1alpha2.ClusterPolicyReport, error) {
+	a := newAuditor(client, policies)
+	if opts.workers < 1 {
+		opts.workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// listErr is set before the resources channel is closed, it is read once all the results are received
+	var listErr error
+	resources := make(chan *unstructured.Unstructured, opts.workers*2)
+	go func() {
+		defer close(resources)
+		for _, kind := range common.GetKindsFromPolicies(policies) {
+			err := common.ListResourcesOfType(ctx, client, kind, opts.namespace, opts.pageSize, func(resource *unstructured.Unstructured) error {
+				select {
+				case resources <- resource:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			if err != nil {
+				listErr = fmt.Errorf("failed to list the %s resources: %w", kind, err)
+				cancel()
+				return
+			}
+		}
+	}()
+
+	results := make(chan []policyreportv1alpha2.PolicyReportResult, opts.workers*2)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for resource := range resources {
+				results <- a.auditResource(resource)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	rc := &common.ResultCounts{}
+	report := &policyreportv1alpha2.ClusterPolicyReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyreportv1alpha2.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicyReport",
+		},
+		Results: []policyreportv1alpha2.PolicyReportResult{},
+	}
+	report.SetName(clusterpolicyreport)
+
+	var audited int
+	start := time.Now()
+	ticker := time.NewTicker(auditProgressPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case resourceResults, ok := <-results:
+			if !ok {
+				printAuditProgress(opts.progress, audited, rc, start, true)
+				if listErr != nil {
+					return rc, nil, listErr
+				}
+				sortReportResults(report.Results)
+				report.Summary = calculateSummary(report.Results)
+				return rc, report, nil
+			}
+
+			audited++
+			countReportResults(rc, resourceResults)
+			printViolations(opts.violations, resourceResults)
+			report.Results = append(report.Results, resourceResults...)
+
+		case <-ticker.C:
+			printAuditProgress(opts.progress, audited, rc, start, false)
+		}
+	}
+}
+
+// auditResource applies the policies to the resource and returns the results of the rules that matched the resource
+func (a *auditor) auditResource(resource *unstructured.Unstructured) []policyreportv1alpha2.PolicyReportResult {
+	var results []policyreportv1alpha2.PolicyReportResult
+	resourceRaw, err := resource.MarshalJSON()
+	if err != nil {
+		log.Log.Error(err, "failed to marshal resource")
+		return nil
+	}
+
+	namespaceLabels := a.getNamespaceLabels(resource.GetNamespace())
+	for _, p := range a.policies {
+		ctx := engineContext.NewContext()
+		if err := engineContext.AddResource(ctx, resourceRaw); err != nil {
+			log.Log.Error(err, "failed to load resource in context")
+		}
+
+		if err := ctx.AddImageInfos(resource); err != nil {
+			log.Log.Error(err, "failed to add image variables to context")
+		}
+
+		policyContext := &engine.PolicyContext{
+			Policy:          p.policy,
+			NewResource:     *resource,
+			JSONContext:     ctx,
+			Client:          a.client,
+			NamespaceLabels: namespaceLabels,
+		}
+
+		var engineResponses []*response.EngineResponse
+		if p.validate {
+			engineResponses = append(engineResponses, engine.Validate(policyContext))
+		}
+
+		if p.verifyImages {
+			verifyImageResponse, _ := engine.VerifyAndPatchImages(policyContext)
+			engineResponses = append(engineResponses, verifyImageResponse)
+		}
+
+		for _, engineResponse := range engineResponses {
+			if engineResponse == nil {
+				continue
+			}
+
+			for _, rule := range engineResponse.PolicyResponse.Rules {
+				results = append(results, p.reportResult(resource, rule))
+			}
+		}
+	}
+
+	return results
+}
+
+func (a *auditor) getNamespaceLabels(namespace string) map[string]string {
+	if namespace == "" {
+		return nil
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if labels, ok := a.namespaceLabels[namespace]; ok {
+		return labels
+	}
+
+	var labels map[string]string
+	ns, err := a.client.GetResource("v1", "Namespace", "", namespace)
+	if err != nil {
+		log.Log.V(3).Info("failed to get namespace labels", "namespace", namespace, "error", err.Error())
+	} else {
+		labels = ns.GetLabels()
+	}
+
+	a.namespaceLabels[namespace] = labels
+	return labels
+}
+
+func (p auditPolicy) reportResult(resource *unstructured.Unstructured, rule response.RuleResponse) policyreportv1alpha2.PolicyReportResult {
+	result := policyreportv1alpha2.PolicyReportResult{
+		Policy:  p.policy.GetName(),
+		Rule:    rule.Name,
+		Message: rule.Message,
+		Result:  common.ToPolicyResult(rule.Status, p.scored),
+		Resources: []corev1.ObjectReference{
+			{
+				Kind:       resource.GetKind(),
+				Namespace:  resource.GetNamespace(),
+				APIVersion: resource.GetAPIVersion(),
+				Name:       resource.GetName(),
+				UID:        types.UID(resource.GetUID()),
+			},
+		},
+		Scored:    p.scored,
+		Category:  p.category,
+		Severity:  p.severity,
+		Source:    policyreport.SourceValue,
+		Timestamp: metav1.Timestamp{Seconds: time.Now().Unix()},
+	}
+	return result
+}
+
+func countReportResults(rc *common.ResultCounts, results []policyreportv1alpha2.PolicyReportResult) {
+	for _, result := range results {
+		switch result.Result {
+		case policyreportv1alpha2.StatusPass:
+			rc.Pass++
+		case policyreportv1alpha2.StatusFail:
+			rc.Fail++
+		case policyreportv1alpha2.StatusWarn:
+			rc.Warn++
+		case policyreportv1alpha2.StatusError:
+			rc.Error++
+		case policyreportv1alpha2.StatusSkip:
+			rc.Skip++
+		}
+	}
+}
+
+// printViolations prints the failed results as the apply command does for local resources
+func printViolations(w io.Writer, results []policyreportv1alpha2.PolicyReportResult) {
+	if w == nil {
+		return
+	}
+
+	var policy string
+	var count int
+	for _, result := range results {
+		if result.Result != policyreportv1alpha2.StatusFail {
+			continue
+		}
+
+		if result.Policy != policy {
+			resource := result.Resources[0]
+			fmt.Fprintf(w, "\npolicy %s -> resource %s/%s/%s failed: \n", result.Policy, resource.Namespace, resource.Kind, resource.Name)
+			policy = result.Policy
+			count = 0
+		}
+
+		count++
+		fmt.Fprintf(w, "%d. %s: %s \n", count, result.Rule, result.Message)
+	}
+}
+
+func printAuditProgress(w io.Writer, audited int, rc *common.ResultCounts, start time.Time, done bool) {
+	if w == nil {
+		return
+	}
+
+	elapsed := time.Since(start).Round(time.Second)
+	fmt.Fprintf(w, "\raudited %d resources in %s, %d violations", audited, elapsed, rc.Fail)
+	if done {
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+func sortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		ri, rj := results[i].Resources[0], results[j].Resources[0]
+		if ri.Namespace != rj.Namespace {
+			return ri.Namespace < rj.Namespace
+		}
+		if ri.Kind != rj.Kind {
+			return ri.Kind < rj.Kind
+		}
+		if ri.Name != rj.Name {
+			return ri.Name < rj.Name
+		}
+		if results[i].Policy != results[j].Policy {
+			return results[i].Policy < results[j].Policy
+		}
+		return results[i].Rule < results[j].Rule
+	})
+}
+
+// writeReport writes the report as YAML to a file
+func writeReport(report *policyreportv1alpha2.ClusterPolicyReport, path string) error {
+	data, err := yaml1.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0o600)
+}
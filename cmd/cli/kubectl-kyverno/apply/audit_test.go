@@ -0,0 +1,140 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	preport "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var rawAuditPolicy = []byte(`
+{
+	"apiVersion": "kyverno.io/v1",
+	"kind": "ClusterPolicy",
+	"metadata": {
+	  "name": "require-owner",
+	  "annotations": {
+		"policies.kyverno.io/category": "Best Practices",
+		"policies.kyverno.io/severity": "medium"
+	  }
+	},
+	"spec": {
+	  "validationFailureAction": "audit",
+	  "rules": [
+		{
+		  "name": "check-owner",
+		  "match": {
+			"resources": {
+			  "kinds": [
+				"ConfigMap"
+			  ]
+			}
+		  },
+		  "validate": {
+			"message": "the owner label is required",
+			"pattern": {
+			  "metadata": {
+				"labels": {
+				  "owner": "?*"
+				}
+			  }
+			}
+		  }
+		}
+	  ]
+	}
+}
+`)
+
+func newConfigMap(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace(namespace)
+	configMap.SetName(name)
+	configMap.SetLabels(labels)
+	return configMap
+}
+
+func Test_auditCluster(t *testing.T) {
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawAuditPolicy, &policy))
+
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+
+	objects := []runtime.Object{
+		newConfigMap("default", "valid", map[string]string{"owner": "team-a"}),
+		newConfigMap("default", "invalid", nil),
+		newConfigMap("test", "invalid", nil),
+	}
+
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	var violations bytes.Buffer
+	rc, report, err := auditCluster(context.Background(), client, []kyverno.PolicyInterface{&policy}, auditOptions{
+		workers:    2,
+		pageSize:   1,
+		violations: &violations,
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, rc.Pass, 1)
+	assert.Equal(t, rc.Fail, 2)
+	assert.Equal(t, report.GetName(), clusterpolicyreport)
+	assert.Equal(t, report.Summary.Pass, 1)
+	assert.Equal(t, report.Summary.Fail, 2)
+	assert.Equal(t, len(report.Results), 3)
+
+	// the results are sorted by resource
+	assert.Equal(t, report.Results[0].Resources[0].Name, "invalid")
+	assert.Equal(t, report.Results[0].Resources[0].Namespace, "default")
+	assert.Equal(t, report.Results[0].Result, preport.PolicyResult(preport.StatusFail))
+	assert.Equal(t, report.Results[0].Category, "Best Practices")
+	assert.Equal(t, report.Results[0].Severity, preport.PolicySeverity(preport.SeverityMedium))
+	assert.Equal(t, report.Results[1].Resources[0].Name, "valid")
+	assert.Equal(t, report.Results[2].Resources[0].Namespace, "test")
+
+	assert.Assert(t, bytes.Contains(violations.Bytes(), []byte("policy require-owner -> resource default/ConfigMap/invalid failed:")))
+	assert.Assert(t, bytes.Contains(violations.Bytes(), []byte("policy require-owner -> resource test/ConfigMap/invalid failed:")))
+
+	// the namespace limits the audit to the resources of the namespace
+	rc, report, err = auditCluster(context.Background(), client, []kyverno.PolicyInterface{&policy}, auditOptions{
+		namespace: "test",
+		workers:   1,
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, rc.Fail, 1)
+	assert.Equal(t, len(report.Results), 1)
+}
+
+func Test_auditCluster_ListError(t *testing.T) {
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawAuditPolicy, &policy))
+	policy.Spec.Rules[0].MatchResources.Kinds = append(policy.Spec.Rules[0].MatchResources.Kinds, "Widget")
+
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, newConfigMap("default", "invalid", nil))
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	// the kinds that can not be listed fail the audit instead of being skipped
+	_, report, err := auditCluster(context.Background(), client, []kyverno.PolicyInterface{&policy}, auditOptions{workers: 1})
+	assert.ErrorContains(t, err, "failed to list the Widget resources")
+	assert.Assert(t, report == nil)
+}
@@ -0,0 +1,530 @@
+package apply
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	yaml1 "sigs.k8s.io/yaml"
+)
+
+const (
+	textOutput  = "text"
+	jsonOutput  = "json"
+	yamlOutput  = "yaml"
+	sarifOutput = "sarif"
+	junitOutput = "junit"
+)
+
+var outputFormats = []string{textOutput, jsonOutput, yamlOutput, sarifOutput, junitOutput}
+
+func validateOutputFormat(format string) error {
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %s, expected one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+// exit codes of the apply command
+const (
+	// exitViolations is returned when a policy rule failed on a resource.
+	exitViolations = 1
+	// exitErrors is returned when the policies could not be applied, or a rule returned an error.
+	// It takes precedence over exitViolations.
+	exitErrors = 2
+)
+
+func exitCode(rc *common.ResultCounts) int {
+	if rc.Error > 0 {
+		return exitErrors
+	}
+	if rc.Fail > 0 {
+		return exitViolations
+	}
+	return 0
+}
+
+// applyWithOutputFormat applies the policies and prints the results in a structured output format to stdout,
+// the messages printed while applying the policies go to stderr
+func applyWithOutputFormat(outputFormat string, resourcePaths []string, renderOpts common.RenderOptions, userInfoPath string, cluster bool, mutateLogPath string,
+	variablesString string, valuesFile string, namespace string, policyPaths []string, registryAccess bool,
+) error {
+	rendered, err := renderResources(renderOpts)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, _, engineResponses, err := applyCommandHelper(os.Stderr, resourcePaths, rendered, userInfoPath, cluster, false, mutateLogPath, variablesString, valuesFile, namespace, policyPaths, false, registryAccess)
+	if err != nil {
+		return err
+	}
+
+	results := buildApplyResults(engineResponses)
+	sortApplyResults(results)
+	rc := countApplyResults(results)
+
+	var resourceFiles map[string]string
+	if outputFormat == sarifOutput && !cluster {
+		resourceFiles = getResourceFiles(resourcePaths, rendered)
+	}
+
+	if err := printApplyReport(os.Stdout, outputFormat, results, rc, resourceFiles); err != nil {
+		return sanitizederror.NewWithError("failed to print the results", err)
+	}
+
+	if code := exitCode(rc); code != 0 {
+		os.Exit(code)
+	}
+	return nil
+}
+
+// ApplyResult is the outcome of a policy rule applied to a resource, as reported by the structured output formats
+type ApplyResult struct {
+	// Policy is the name of the policy, prefixed by its namespace for namespaced policies.
+	Policy string `json:"policy"`
+	// Rule is the name of the rule in the policy.
+	Rule string `json:"rule"`
+	// Type is the type of the rule: Mutation, Validation, Generation or ImageVerify.
+	Type response.RuleType `json:"type"`
+	// Resource is the resource formatted as namespace/kind/name.
+	Resource string `json:"resource"`
+	// Result is the result of the rule: pass, fail, warn, error or skip.
+	Result policyreportv1alpha2.PolicyResult `json:"result"`
+	// Message is the message of the rule.
+	Message string `json:"message,omitempty"`
+	// Patches are the JSON patches applied by a mutate rule.
+	Patches []json.RawMessage `json:"patches,omitempty"`
+	// GeneratedResource is the resource generated by a generate rule.
+	GeneratedResource map[string]interface{} `json:"generatedResource,omitempty"`
+
+	// category and severity come from the policy annotations
+	category string
+	severity policyreportv1alpha2.PolicySeverity
+}
+
+func (r ApplyResult) name() string {
+	return fmt.Sprintf("%s/%s/%s", r.Policy, r.Rule, r.Resource)
+}
+
+// applySummary counts the results by result
+type applySummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// applyReport is the report printed by the json and yaml output formats
+type applyReport struct {
+	Summary applySummary  `json:"summary"`
+	Results []ApplyResult `json:"results"`
+}
+
+// buildApplyResults flattens the engine responses into one result per rule and resource
+func buildApplyResults(engineResponses []*response.EngineResponse) []ApplyResult {
+	results := []ApplyResult{}
+	for _, engineResponse := range engineResponses {
+		if engineResponse == nil {
+			continue
+		}
+
+		policyName := engineResponse.PolicyResponse.Policy.Name
+		if ns := engineResponse.PolicyResponse.Policy.Namespace; ns != "" {
+			policyName = ns + "/" + policyName
+		}
+
+		p := auditPolicy{scored: true}
+		if engineResponse.Policy != nil {
+			p = newAuditPolicy(engineResponse.Policy)
+		}
+
+		resource := engineResponse.PolicyResponse.Resource
+		for _, rule := range engineResponse.PolicyResponse.Rules {
+			result := ApplyResult{
+				Policy:   policyName,
+				Rule:     rule.Name,
+				Type:     rule.Type,
+				Resource: fmt.Sprintf("%s/%s/%s", resource.Namespace, resource.Kind, resource.Name),
+				Result:   common.ToPolicyResult(rule.Status, p.scored),
+				Message:  rule.Message,
+				category: p.category,
+				severity: p.severity,
+			}
+
+			for _, patch := range rule.Patches {
+				result.Patches = append(result.Patches, json.RawMessage(patch))
+			}
+
+			if len(rule.GeneratedResource.Object) > 0 {
+				result.GeneratedResource = rule.GeneratedResource.Object
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+func countApplyResults(results []ApplyResult) *common.ResultCounts {
+	rc := &common.ResultCounts{}
+	for _, result := range results {
+		switch result.Result {
+		case policyreportv1alpha2.StatusPass:
+			rc.Pass++
+		case policyreportv1alpha2.StatusFail:
+			rc.Fail++
+		case policyreportv1alpha2.StatusWarn:
+			rc.Warn++
+		case policyreportv1alpha2.StatusError:
+			rc.Error++
+		case policyreportv1alpha2.StatusSkip:
+			rc.Skip++
+		}
+	}
+	return rc
+}
+
+func printApplyReport(w io.Writer, format string, results []ApplyResult, rc *common.ResultCounts, resourceFiles map[string]string) error {
+	switch format {
+	case jsonOutput, yamlOutput:
+		report := applyReport{
+			Summary: applySummary{Pass: rc.Pass, Fail: rc.Fail, Warn: rc.Warn, Error: rc.Error, Skip: rc.Skip},
+			Results: results,
+		}
+		return printJSONOrYAMLReport(w, format, report)
+	case sarifOutput:
+		return printSARIFReport(w, results, resourceFiles)
+	case junitOutput:
+		return printJUnitReport(w, results, rc)
+	default:
+		return nil
+	}
+}
+
+func printJSONOrYAMLReport(w io.Writer, format string, report applyReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if format == yamlOutput {
+		if data, err = yaml1.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel maps the result of a rule to a SARIF level, passed and skipped rules are not reported
+func sarifLevel(result policyreportv1alpha2.PolicyResult) string {
+	switch result {
+	case policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusError:
+		return "error"
+	case policyreportv1alpha2.StatusWarn:
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+// printSARIFReport prints the failed, warned and errored rules as SARIF results,
+// located in the resource files when they are known so that code scanning tools can annotate them
+func printSARIFReport(w io.Writer, results []ApplyResult, resourceFiles map[string]string) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "kyverno",
+				InformationURI: "https://kyverno.io",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]int{}
+	for _, result := range results {
+		level := sarifLevel(result.Result)
+		if level == "" {
+			continue
+		}
+
+		id := result.Policy + "/" + result.Rule
+		index, ok := rules[id]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			rules[id] = index
+			rule := sarifRule{
+				ID:               id,
+				Name:             result.Rule,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("rule %s of policy %s", result.Rule, result.Policy)},
+			}
+			if result.category != "" || result.severity != "" {
+				rule.Properties = map[string]string{}
+				if result.category != "" {
+					rule.Properties["category"] = result.category
+				}
+				if result.severity != "" {
+					rule.Properties["severity"] = string(result.severity)
+				}
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+
+		message := result.Message
+		if message == "" {
+			message = fmt.Sprintf("%s %s", id, result.Result)
+		}
+
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: result.Resource, Kind: "resource"}},
+		}
+		if file, ok := resourceFiles[result.Resource]; ok {
+			location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)}}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     level,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s: %s", result.Resource, message)},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string           `xml:"name,attr"`
+	Classname string           `xml:"classname,attr"`
+	Failure   *junitFailure    `xml:"failure,omitempty"`
+	Error     *junitFailure    `xml:"error,omitempty"`
+	Skipped   *junitSkipped    `xml:"skipped,omitempty"`
+	SystemOut *junitSystemText `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitSystemText struct {
+	Text string `xml:",chardata"`
+}
+
+// printJUnitReport prints a test suite per policy and a test case per rule and resource,
+// failed rules are reported as failures and rule errors as errors
+func printJUnitReport(w io.Writer, results []ApplyResult, rc *common.ResultCounts) error {
+	report := junitTestSuites{
+		Name:     "kyverno",
+		Tests:    len(results),
+		Failures: rc.Fail,
+		Errors:   rc.Error,
+		Skipped:  rc.Skip,
+	}
+
+	suites := map[string]int{}
+	for _, result := range results {
+		i, ok := suites[result.Policy]
+		if !ok {
+			i = len(report.Suites)
+			suites[result.Policy] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: result.Policy})
+		}
+
+		suite := &report.Suites[i]
+		testCase := junitTestCase{Name: result.name(), Classname: result.Policy}
+		switch result.Result {
+		case policyreportv1alpha2.StatusFail:
+			testCase.Failure = &junitFailure{Message: result.Message, Type: string(result.Type), Text: result.Message}
+			suite.Failures++
+		case policyreportv1alpha2.StatusError:
+			testCase.Error = &junitFailure{Message: result.Message, Type: string(result.Type), Text: result.Message}
+			suite.Errors++
+		case policyreportv1alpha2.StatusSkip:
+			testCase.Skipped = &junitSkipped{Message: result.Message}
+			suite.Skipped++
+		default:
+			if result.Message != "" {
+				testCase.SystemOut = &junitSystemText{Text: result.Message}
+			}
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// getResourceFiles maps the resources formatted as namespace/kind/name to the file they were loaded or rendered from,
+// directories are expanded to their yaml files like the resources are loaded
+func getResourceFiles(resourcePaths []string, rendered []common.RenderedResource) map[string]string {
+	files := map[string]string{}
+	for _, r := range rendered {
+		files[fmt.Sprintf("%s/%s/%s", r.Resource.GetNamespace(), r.Resource.GetKind(), r.Resource.GetName())] = r.Source
+	}
+
+	if len(resourcePaths) > 0 {
+		if info, err := os.Stat(resourcePaths[0]); err == nil && info.IsDir() {
+			entries, err := ioutil.ReadDir(resourcePaths[0])
+			if err != nil {
+				return files
+			}
+			var paths []string
+			for _, entry := range entries {
+				ext := filepath.Ext(entry.Name())
+				if ext == ".yaml" || ext == ".yml" {
+					paths = append(paths, filepath.Join(resourcePaths[0], entry.Name()))
+				}
+			}
+			resourcePaths = paths
+		}
+	}
+
+	for _, path := range resourcePaths {
+		data, err := ioutil.ReadFile(filepath.Clean(path)) // #nosec G304
+		if err != nil {
+			continue
+		}
+
+		resources, err := common.GetResource(data)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resources {
+			key := fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+			if _, ok := files[key]; !ok {
+				files[key] = path
+			}
+		}
+	}
+
+	return files
+}
+
+// sortApplyResults sorts the results by policy, rule and resource
+func sortApplyResults(results []ApplyResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].name() < results[j].name()
+	})
+}
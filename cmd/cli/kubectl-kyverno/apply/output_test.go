@@ -0,0 +1,190 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	preport "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/policyreport"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newEngineResponse(policy string, rules ...response.RuleResponse) *response.EngineResponse {
+	return &response.EngineResponse{
+		PolicyResponse: response.PolicyResponse{
+			Policy:   response.PolicySpec{Name: policy},
+			Resource: response.ResourceSpec{Kind: "Pod", Namespace: "default", Name: "nginx"},
+			Rules:    rules,
+		},
+	}
+}
+
+func Test_buildApplyResults(t *testing.T) {
+	results := buildApplyResults([]*response.EngineResponse{
+		newEngineResponse("add-labels", response.RuleResponse{
+			Name:    "add-team",
+			Type:    response.Mutation,
+			Status:  response.RuleStatusPass,
+			Patches: [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/team","value":"a"}`)},
+		}),
+		newEngineResponse("require-labels", response.RuleResponse{
+			Name:    "check-team",
+			Type:    response.Validation,
+			Status:  response.RuleStatusFail,
+			Message: "the team label is required",
+		}),
+		newEngineResponse("add-quota", response.RuleResponse{
+			Name:              "generate-quota",
+			Type:              response.Generation,
+			Status:            response.RuleStatusPass,
+			GeneratedResource: unstructured.Unstructured{Object: map[string]interface{}{"kind": "ResourceQuota"}},
+		}),
+		nil,
+	})
+
+	assert.Equal(t, len(results), 3)
+	assert.Equal(t, results[0].Resource, "default/Pod/nginx")
+	assert.Equal(t, results[0].Result, preport.PolicyResult(preport.StatusPass))
+	assert.Equal(t, len(results[0].Patches), 1)
+	assert.Equal(t, results[1].Result, preport.PolicyResult(preport.StatusFail))
+	assert.Equal(t, results[1].Message, "the team label is required")
+	assert.Equal(t, results[2].GeneratedResource["kind"], "ResourceQuota")
+
+	rc := countApplyResults(results)
+	assert.Equal(t, rc.Pass, 2)
+	assert.Equal(t, rc.Fail, 1)
+
+	var buf bytes.Buffer
+	assert.NilError(t, printApplyReport(&buf, jsonOutput, results, rc, nil))
+	var report applyReport
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, report.Summary.Fail, 1)
+	assert.Equal(t, len(report.Results), 3)
+	var patch map[string]interface{}
+	assert.NilError(t, json.Unmarshal(report.Results[0].Patches[0], &patch))
+	assert.DeepEqual(t, patch, map[string]interface{}{"op": "add", "path": "/metadata/labels/team", "value": "a"})
+}
+
+func Test_buildApplyResults_NotScored(t *testing.T) {
+	policy := &kyverno.ClusterPolicy{}
+	policy.SetName("require-labels")
+	policy.SetAnnotations(map[string]string{policyreport.ScoredLabel: "false"})
+
+	engineResponse := newEngineResponse("require-labels", response.RuleResponse{
+		Name:    "check-team",
+		Type:    response.Validation,
+		Status:  response.RuleStatusFail,
+		Message: "the team label is required",
+	})
+	engineResponse.Policy = policy
+
+	// the failures of the policies that are not scored are reported as warnings, like the text output and the audit do
+	results := buildApplyResults([]*response.EngineResponse{engineResponse})
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, results[0].Result, preport.PolicyResult(preport.StatusWarn))
+
+	rc := countApplyResults(results)
+	assert.Equal(t, rc.Fail, 0)
+	assert.Equal(t, rc.Warn, 1)
+	assert.Equal(t, exitCode(rc), 0)
+}
+
+func Test_exitCode(t *testing.T) {
+	assert.Equal(t, exitCode(&common.ResultCounts{Pass: 1, Warn: 1, Skip: 1}), 0)
+	assert.Equal(t, exitCode(&common.ResultCounts{Fail: 1}), exitViolations)
+	assert.Equal(t, exitCode(&common.ResultCounts{Fail: 1, Error: 1}), exitErrors)
+}
+
+func Test_printSARIFReport(t *testing.T) {
+	results := []ApplyResult{
+		{Policy: "require-labels", Rule: "check-team", Resource: "default/Pod/nginx", Result: preport.StatusFail, Message: "the team label is required", severity: preport.SeverityHigh},
+		{Policy: "require-labels", Rule: "check-team", Resource: "default/Pod/redis", Result: preport.StatusWarn},
+		{Policy: "require-labels", Rule: "check-team", Resource: "default/Pod/busybox", Result: preport.StatusPass},
+	}
+
+	var buf bytes.Buffer
+	assert.NilError(t, printSARIFReport(&buf, results, map[string]string{"default/Pod/nginx": "resources/nginx.yaml"}))
+
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, log.Version, sarifVersion)
+	assert.Equal(t, len(log.Runs), 1)
+
+	run := log.Runs[0]
+	assert.Equal(t, len(run.Tool.Driver.Rules), 1)
+	assert.Equal(t, run.Tool.Driver.Rules[0].ID, "require-labels/check-team")
+	assert.Equal(t, run.Tool.Driver.Rules[0].Properties["severity"], "high")
+
+	// passed rules are not reported
+	assert.Equal(t, len(run.Results), 2)
+	assert.Equal(t, run.Results[0].Level, "error")
+	assert.Equal(t, run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "resources/nginx.yaml")
+	assert.Equal(t, run.Results[1].Level, "warning")
+	assert.Assert(t, run.Results[1].Locations[0].PhysicalLocation == nil)
+	assert.Equal(t, run.Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName, "default/Pod/redis")
+}
+
+func Test_printJUnitReport(t *testing.T) {
+	results := []ApplyResult{
+		{Policy: "require-labels", Rule: "check-team", Type: response.Validation, Resource: "default/Pod/nginx", Result: preport.StatusFail, Message: "the team label is required"},
+		{Policy: "require-labels", Rule: "check-team", Type: response.Validation, Resource: "default/Pod/redis", Result: preport.StatusError, Message: "failed to substitute variables"},
+		{Policy: "add-labels", Rule: "add-team", Type: response.Mutation, Resource: "default/Pod/nginx", Result: preport.StatusPass},
+	}
+
+	var buf bytes.Buffer
+	assert.NilError(t, printJUnitReport(&buf, results, countApplyResults(results)))
+
+	var report junitTestSuites
+	assert.NilError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, report.Tests, 3)
+	assert.Equal(t, report.Failures, 1)
+	assert.Equal(t, report.Errors, 1)
+	assert.Equal(t, len(report.Suites), 2)
+	assert.Equal(t, report.Suites[0].Name, "require-labels")
+	assert.Assert(t, report.Suites[0].Cases[0].Failure != nil)
+	assert.Assert(t, report.Suites[0].Cases[1].Error != nil)
+}
+
+func Test_getResourceFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resources")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	pods := filepath.Join(dir, "pods.yaml")
+	assert.NilError(t, ioutil.WriteFile(pods, []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: redis
+  namespace: test
+`), 0o600))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# resources"), 0o600))
+
+	files := getResourceFiles([]string{dir}, nil)
+	assert.DeepEqual(t, files, map[string]string{
+		"default/Pod/nginx": pods,
+		"test/Pod/redis":    pods,
+	})
+
+	// the rendered resources are located in the files they were rendered from
+	deployment := &unstructured.Unstructured{}
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("prod")
+	deployment.SetName("web")
+	template := filepath.Join("chart", "templates", "deployment.yaml")
+	files = getResourceFiles(nil, []common.RenderedResource{{Resource: deployment, Source: template}})
+	assert.DeepEqual(t, files, map[string]string{"prod/Deployment/web": template})
+}
@@ -0,0 +1,62 @@
+package apply
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/watch"
+)
+
+// watchApply applies the policies on the resources, then applies them again when the policy, resource, kustomization, chart, values or user info files change
+func watchApply(resourcePaths []string, renderOpts common.RenderOptions, userInfoPath string, policyReport bool, mutateLogPath string,
+	variablesString string, valuesFile string, policyPaths []string, registryAccess bool,
+) error {
+	paths := append([]string{}, policyPaths...)
+	paths = append(paths, resourcePaths...)
+	paths = append(paths, renderOpts.Paths()...)
+	paths = append(paths, valuesFile, userInfoPath)
+
+	watcher, err := watch.New(paths...)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to watch the policy and resource files", err)
+	}
+	defer watcher.Close()
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	for {
+		rendered, err := renderResources(renderOpts)
+		if err != nil {
+			fmt.Printf("\nError: %s\n", err)
+		} else if rc, resources, skipInvalidPolicies, pvInfos, _, err := applyCommandHelper(os.Stdout, resourcePaths, rendered, userInfoPath, false, policyReport, mutateLogPath, variablesString, valuesFile, "", policyPaths, false, registryAccess); err != nil {
+			fmt.Printf("\nError: %s\n", err)
+		} else {
+			printReportOrViolation(policyReport, rc, len(resources), skipInvalidPolicies, false, pvInfos)
+		}
+
+		fmt.Printf("\nWatching for changes, press Ctrl+C to stop...\n")
+		changed, err := watcher.Wait(stop)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to watch the policy and resource files", err)
+		}
+
+		if changed == nil {
+			return nil
+		}
+
+		for _, file := range changed {
+			fmt.Printf("\n%s changed\n", file)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// userInfoKeys are the keys of the user info inlined in match and exclude
+var userInfoKeys = []string{"roles", "clusterRoles", "subjects"}
+
+// deprecatedOperators maps the deprecated condition operators to their replacement
+var deprecatedOperators = map[string]string{
+	"Equal":    "Equals",
+	"NotEqual": "NotEquals",
+}
+
+// report lists the fixes applied to the policies, and the deprecated syntax that could not be fixed
+type report struct {
+	prefix   string
+	fixes    []string
+	warnings []string
+}
+
+func (r *report) fix(format string, args ...interface{}) {
+	r.fixes = append(r.fixes, r.prefix+fmt.Sprintf(format, args...))
+}
+
+func (r *report) warn(format string, args ...interface{}) {
+	r.warnings = append(r.warnings, r.prefix+fmt.Sprintf(format, args...))
+}
+
+// fixPolicies rewrites the deprecated syntax of the policies in a YAML stream to the canonical form,
+// the comments and the other documents are kept. The stream is returned unchanged when there is nothing to fix.
+func fixPolicies(data []byte) ([]byte, *report, error) {
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, err
+		}
+		documents = append(documents, &document)
+	}
+
+	r := &report{}
+	for _, document := range documents {
+		if len(document.Content) > 0 {
+			fixPolicy(document.Content[0], r)
+		}
+	}
+
+	if len(r.fixes) == 0 {
+		return data, r, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoderWithOptions(&buf, &yaml.EncoderOptions{SeqIndent: yaml.SequenceIndentStyle(yaml.DeriveSeqIndentStyle(string(data)))})
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return buf.Bytes(), r, nil
+}
+
+// fixPolicy rewrites the rules of a ClusterPolicy or Policy, other resources are left untouched
+func fixPolicy(policy *yaml.Node, r *report) {
+	if policy.Kind != yaml.MappingNode {
+		return
+	}
+
+	apiVersion := scalarValue(mapValue(policy, "apiVersion"))
+	kind := scalarValue(mapValue(policy, "kind"))
+	if !strings.HasPrefix(apiVersion, "kyverno.io/") || (kind != "ClusterPolicy" && kind != "Policy") {
+		return
+	}
+
+	name := scalarValue(mapValue(mapValue(policy, "metadata"), "name"))
+	rules := mapValue(mapValue(policy, "spec"), "rules")
+	if rules == nil || rules.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, rule := range rules.Content {
+		if rule.Kind == yaml.MappingNode {
+			r.prefix = fmt.Sprintf("%s/%s: ", name, scalarValue(mapValue(rule, "name")))
+			fixRule(rule, r)
+		}
+	}
+	r.prefix = ""
+}
+
+func fixRule(rule *yaml.Node, r *report) {
+	for _, block := range []string{"match", "exclude"} {
+		if filters := mapValue(rule, block); filters != nil && filters.Kind == yaml.MappingNode {
+			fixResourceFilters(block, filters, r)
+		}
+	}
+
+	if verifyImages := mapValue(rule, "verifyImages"); verifyImages != nil && verifyImages.Kind == yaml.SequenceNode {
+		for i, imageVerification := range verifyImages.Content {
+			if imageVerification.Kind == yaml.MappingNode {
+				fixImageVerification(fmt.Sprintf("verifyImages[%d]", i), imageVerification, r)
+			}
+		}
+	}
+
+	fixOperators(rule, r)
+}
+
+// fixResourceFilters moves the resources and user info specified directly under match or exclude to any,
+// or to all when all is already used
+func fixResourceFilters(block string, filters *yaml.Node, r *report) {
+	if resources := mapValue(filters, "resources"); resources != nil {
+		fixResourceDescription(block+".resources", resources, r)
+	}
+
+	for _, list := range []string{"any", "all"} {
+		if filterList := mapValue(filters, list); filterList != nil && filterList.Kind == yaml.SequenceNode {
+			for i, filter := range filterList.Content {
+				if resources := mapValue(filter, "resources"); resources != nil {
+					fixResourceDescription(fmt.Sprintf("%s.%s[%d].resources", block, list, i), resources, r)
+				}
+			}
+		}
+	}
+
+	var keys []string
+	for _, key := range append([]string{"resources"}, userInfoKeys...) {
+		if mapValue(filters, key) != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	if mapValue(filters, "any") != nil {
+		r.warn("%s: cannot move %s to %s.any as it is already used, migrate them manually", block, strings.Join(keys, ", "), block)
+		return
+	}
+
+	filter := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range keys {
+		keyNode, value := mapRemove(filters, key)
+		filter.Content = append(filter.Content, keyNode, value)
+	}
+
+	if all := mapValue(filters, "all"); all != nil && all.Kind == yaml.SequenceNode {
+		all.Content = append(all.Content, filter)
+		r.fix("%s: moved %s to %s.all", block, strings.Join(keys, ", "), block)
+		return
+	}
+
+	mapSet(filters, "any", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{filter}})
+	r.fix("%s: moved %s to %s.any", block, strings.Join(keys, ", "), block)
+}
+
+// fixResourceDescription replaces name with names
+func fixResourceDescription(path string, resources *yaml.Node, r *report) {
+	if resources.Kind != yaml.MappingNode || mapValue(resources, "name") == nil {
+		return
+	}
+
+	if mapValue(resources, "names") != nil {
+		r.warn("%s: name and names are both specified, migrate them manually", path)
+		return
+	}
+
+	keyNode, name := mapRemove(resources, "name")
+	keyNode.Value = "names"
+	resources.Content = append(resources.Content, keyNode, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{name}})
+	r.fix("%s: replaced name with names", path)
+}
+
+// fixImageVerification replaces the deprecated image, key, keyless and annotations fields with imageReferences and attestors,
+// the same way ImageVerification.Convert does when the policy is loaded
+func fixImageVerification(path string, iv *yaml.Node, r *report) {
+	if mapValue(iv, "image") == nil && mapValue(iv, "key") == nil && mapValue(iv, "issuer") == nil {
+		var keys []string
+		for _, key := range []string{"annotations", "subject", "roots"} {
+			if mapValue(iv, key) != nil {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			r.warn("%s: %s apply to all attestors, migrate them manually", path, strings.Join(keys, ", "))
+		}
+		return
+	}
+
+	if keyNode, image := mapRemove(iv, "image"); keyNode != nil {
+		imageReferences := mapValue(iv, "imageReferences")
+		if imageReferences == nil {
+			keyNode.Value = "imageReferences"
+			imageReferences = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			iv.Content = append(iv.Content, keyNode, imageReferences)
+		}
+		imageReferences.Content = append(imageReferences.Content, image)
+		r.fix("%s: replaced image with imageReferences", path)
+	}
+
+	attestor := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if keyNode, annotations := mapRemove(iv, "annotations"); keyNode != nil {
+		attestor.Content = append(attestor.Content, keyNode, annotations)
+	}
+
+	keyless := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range []string{"issuer", "subject", "roots"} {
+		if keyNode, value := mapRemove(iv, key); keyNode != nil {
+			keyless.Content = append(keyless.Content, keyNode, value)
+		}
+	}
+
+	if keyNode, key := mapRemove(iv, "key"); keyNode != nil {
+		keyNode.Value = "publicKeys"
+		mapSet(attestor, "keys", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{keyNode, key}})
+		if len(keyless.Content) > 0 {
+			r.fix("%s: removed the keyless fields ignored along with a key", path)
+		}
+	} else if mapValue(keyless, "issuer") != nil {
+		mapSet(attestor, "keyless", keyless)
+	} else if len(keyless.Content) > 0 {
+		r.fix("%s: removed the keyless fields ignored without an issuer", path)
+	}
+
+	if mapValue(iv, "additionalExtensions") != nil {
+		r.warn("%s: additionalExtensions is ignored outside of a keyless attestor, migrate it manually", path)
+	}
+
+	if len(attestor.Content) == 0 {
+		return
+	}
+
+	attestorSet := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapSet(attestorSet, "entries", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{attestor}})
+	attestors := mapValue(iv, "attestors")
+	if attestors == nil {
+		attestors = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		mapSet(iv, "attestors", attestors)
+	}
+	attestors.Content = append(attestors.Content, attestorSet)
+	r.fix("%s: replaced the key, keyless and annotations fields with attestors", path)
+}
+
+// fixOperators replaces the deprecated operators of the conditions in a rule
+func fixOperators(node *yaml.Node, r *report) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if mapValue(node, "key") != nil {
+			if operator := mapValue(node, "operator"); operator != nil && operator.Kind == yaml.ScalarNode {
+				if replacement, ok := deprecatedOperators[operator.Value]; ok {
+					r.fix("replaced the %s operator with %s", operator.Value, replacement)
+					operator.Value = replacement
+				}
+			}
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			fixOperators(node.Content[i], r)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			fixOperators(child, r)
+		}
+	}
+}
+
+// mapValue returns the value of a key in a mapping node, nil if the key is not found
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mapRemove removes a key from a mapping node and returns the key and value nodes, nil if the key is not found
+func mapRemove(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			keyNode, value := node.Content[i], node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return keyNode, value
+		}
+	}
+	return nil, nil
+}
+
+// mapSet adds a key to a mapping node
+func mapSet(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
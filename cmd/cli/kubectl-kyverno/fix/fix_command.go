@@ -0,0 +1,158 @@
+package fix
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var fixHelp = `
+Rewrites the deprecated syntax of the policies to the current canonical form:
+
+  - match and exclude resources and user info are moved to any, or appended to all when it is already used
+  - name is replaced with names in the resource descriptions
+  - image, key, issuer, subject, roots and annotations of verifyImages are replaced with imageReferences and attestors
+  - the Equal and NotEqual operators are replaced with Equals and NotEquals
+
+The files are rewritten in place, comments are preserved. The syntax that cannot be migrated safely is reported as a warning.
+
+Examples:
+
+  # Preview the changes as a diff without writing the files
+  kyverno fix /path/to/policies --dry-run
+
+  # Fix the policies in place
+  kyverno fix /path/to/policy1.yaml /path/to/policy2.yaml
+
+For more information visit https://kyverno.io/docs/kyverno-cli/#fix
+`
+
+// Command returns fix command
+func Command() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:          "fix",
+		Short:        "Migrates deprecated syntax in policy files to the current canonical form",
+		SilenceUsage: true,
+		Example:      fixHelp,
+		Args:         cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, policyPaths []string) error {
+			files, err := getPolicyFiles(policyPaths)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fixed, failed := 0, 0
+			for _, file := range files {
+				changed, err := fixFile(out, file, dryRun)
+				if err != nil {
+					// keep going, the other files can still be fixed
+					fmt.Fprintf(cmd.ErrOrStderr(), "failed to fix %s: %v\n", file, err)
+					failed++
+				}
+				if changed {
+					fixed++
+				}
+			}
+
+			if dryRun {
+				fmt.Fprintf(out, "\n%d of %d files would be fixed\n", fixed, len(files))
+			} else {
+				fmt.Fprintf(out, "\n%d of %d files fixed\n", fixed, len(files))
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to fix %d files", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the changes as a unified diff without writing the files")
+	return cmd
+}
+
+// fixFile fixes the policies of a file, in dry run mode the diff is printed instead of writing the file
+func fixFile(out io.Writer, file string, dryRun bool) (bool, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return false, err
+	}
+
+	fixedData, r, err := fixPolicies(data)
+	if err != nil {
+		return false, err
+	}
+
+	for _, fix := range r.fixes {
+		fmt.Fprintf(out, "%s: fixed %s\n", file, fix)
+	}
+	for _, warning := range r.warnings {
+		fmt.Fprintf(out, "%s: warning %s\n", file, warning)
+	}
+
+	if len(r.fixes) == 0 {
+		return false, nil
+	}
+
+	if dryRun {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(data)),
+			B:        difflib.SplitLines(string(fixedData)),
+			FromFile: file,
+			ToFile:   file + " (fixed)",
+			Context:  3,
+		})
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprint(out, diff)
+		return true, nil
+	}
+
+	return true, ioutil.WriteFile(file, fixedData, info.Mode().Perm())
+}
+
+// getPolicyFiles returns the YAML files of the paths, directories are walked recursively
+func getPolicyFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := strings.ToLower(filepath.Ext(file))
+			if !info.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
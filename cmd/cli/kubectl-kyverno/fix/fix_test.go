@@ -0,0 +1,171 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_fixPolicies(t *testing.T) {
+	policy := `# require signed images
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-images
+spec:
+  rules:
+  - name: check-signature
+    match:
+      # only pods
+      resources:
+        kinds:
+        - Pod
+        name: nginx-*
+    preconditions:
+      all:
+      - key: "{{ request.operation }}"
+        operator: NotEqual
+        value: DELETE
+    verifyImages:
+    - image: ghcr.io/kyverno/*
+      key: |-
+        -----BEGIN PUBLIC KEY-----
+        abc
+        -----END PUBLIC KEY-----
+      subject: ignored
+  - name: check-keyless
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+      resources:
+        kinds:
+        - Deployment
+    verifyImages:
+    - image: ghcr.io/kyverno/*
+      issuer: https://token.actions.githubusercontent.com
+      subject: https://github.com/kyverno/*
+      annotations:
+        env: prod
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  name: value
+`
+
+	expected := `# require signed images
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-images
+spec:
+  rules:
+  - name: check-signature
+    match:
+      any:
+      - # only pods
+        resources:
+          kinds:
+          - Pod
+          names:
+          - nginx-*
+    preconditions:
+      all:
+      - key: "{{ request.operation }}"
+        operator: NotEquals
+        value: DELETE
+    verifyImages:
+    - imageReferences:
+      - ghcr.io/kyverno/*
+      attestors:
+      - entries:
+        - keys:
+            publicKeys: |-
+              -----BEGIN PUBLIC KEY-----
+              abc
+              -----END PUBLIC KEY-----
+  - name: check-keyless
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+      resources:
+        kinds:
+        - Deployment
+    verifyImages:
+    - imageReferences:
+      - ghcr.io/kyverno/*
+      attestors:
+      - entries:
+        - annotations:
+            env: prod
+          keyless:
+            issuer: https://token.actions.githubusercontent.com
+            subject: https://github.com/kyverno/*
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  name: value
+`
+
+	fixed, r, err := fixPolicies([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, string(fixed), expected)
+	assert.DeepEqual(t, r.fixes, []string{
+		"check-images/check-signature: match.resources: replaced name with names",
+		"check-images/check-signature: match: moved resources to match.any",
+		"check-images/check-signature: verifyImages[0]: replaced image with imageReferences",
+		"check-images/check-signature: verifyImages[0]: removed the keyless fields ignored along with a key",
+		"check-images/check-signature: verifyImages[0]: replaced the key, keyless and annotations fields with attestors",
+		"check-images/check-signature: replaced the NotEqual operator with NotEquals",
+		"check-images/check-keyless: verifyImages[0]: replaced image with imageReferences",
+		"check-images/check-keyless: verifyImages[0]: replaced the key, keyless and annotations fields with attestors",
+	})
+	assert.DeepEqual(t, r.warnings, []string{
+		"check-images/check-keyless: match: cannot move resources to match.any as it is already used, migrate them manually",
+	})
+
+	// fixing is idempotent
+	again, r, err := fixPolicies(fixed)
+	assert.NilError(t, err)
+	assert.Equal(t, string(again), string(fixed))
+	assert.Equal(t, len(r.fixes), 0)
+}
+
+func Test_fixPolicies_Unchanged(t *testing.T) {
+	policy := `apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: add-labels
+spec:
+  rules:
+  - name: add-team
+    match:
+      any:
+      - resources:
+          kinds: [Pod]   # flow style is kept
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: a
+`
+
+	fixed, r, err := fixPolicies([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, string(fixed), policy)
+	assert.Equal(t, len(r.fixes), 0)
+	assert.Equal(t, len(r.warnings), 0)
+
+	_, _, err = fixPolicies([]byte("kind: [Pod"))
+	assert.Assert(t, err != nil && strings.Contains(err.Error(), "yaml"))
+}
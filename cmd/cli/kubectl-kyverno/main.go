@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/version"
@@ -28,6 +29,7 @@ func main() {
 		apply.Command(),
 		test.Command(),
 		jp.Command(),
+		fix.Command(),
 	}
 
 	cli.AddCommand(commands...)
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+)
+
+// PatchOperation is a JSON patch operation expected in the patches of a mutate rule
+type PatchOperation struct {
+	// Op is the patch operation, e.g. add, replace or remove.
+	Op string `json:"op"`
+	// Path is the JSON pointer of the patched field.
+	Path string `json:"path"`
+	// Value is the value of the operation, it is only compared when set.
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (t TestResults) hasAssertions() bool {
+	return t.Message != "" || t.MessagePattern != "" || len(t.Patches) > 0 || t.PatchedResourceCondition != "" || t.GeneratedResourceCount != nil
+}
+
+// checkAssertions checks the assertions of the test result against the responses of the rule for the resource
+func checkAssertions(test TestResults, policyNamespace, policy, rule, resource string, engineResponses []*response.EngineResponse) error {
+	if !test.hasAssertions() {
+		return nil
+	}
+
+	resp, ruleResp := findRuleResponse(engineResponses, policyNamespace, policy, rule, test.Namespace, test.Kind, resource)
+	if ruleResp == nil {
+		return fmt.Errorf("rule response not found")
+	}
+
+	if test.Message != "" && ruleResp.Message != test.Message {
+		return fmt.Errorf("expected message %q, got %q", test.Message, ruleResp.Message)
+	}
+
+	if test.MessagePattern != "" {
+		re, err := regexp.Compile(test.MessagePattern)
+		if err != nil {
+			return fmt.Errorf("invalid message pattern %s: %v", test.MessagePattern, err)
+		}
+
+		if !re.MatchString(ruleResp.Message) {
+			return fmt.Errorf("expected message matching %q, got %q", test.MessagePattern, ruleResp.Message)
+		}
+	}
+
+	for _, patch := range test.Patches {
+		found, err := hasPatch(ruleResp.Patches, patch)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("patch %s %s not found", patch.Op, patch.Path)
+		}
+	}
+
+	if test.PatchedResourceCondition != "" {
+		jp, err := jmespath.New(test.PatchedResourceCondition)
+		if err != nil {
+			return fmt.Errorf("invalid patched resource condition %s: %v", test.PatchedResourceCondition, err)
+		}
+
+		result, err := jp.Search(resp.PatchedResource.UnstructuredContent())
+		if err != nil {
+			return fmt.Errorf("failed to evaluate patched resource condition %s: %v", test.PatchedResourceCondition, err)
+		}
+
+		if result != true {
+			return fmt.Errorf("patched resource condition %s evaluated to %v", test.PatchedResourceCondition, result)
+		}
+	}
+
+	if test.GeneratedResourceCount != nil {
+		count := countGeneratedResources(engineResponses, policyNamespace, policy, rule, test.Namespace, test.Kind, resource)
+		if count != *test.GeneratedResourceCount {
+			return fmt.Errorf("expected %d generated resources, got %d", *test.GeneratedResourceCount, count)
+		}
+	}
+
+	return nil
+}
+
+func findRuleResponse(engineResponses []*response.EngineResponse, policyNamespace, policy, rule, namespace, kind, resource string) (*response.EngineResponse, *response.RuleResponse) {
+	for _, resp := range engineResponses {
+		if !matchesResponse(resp, policyNamespace, policy, namespace, kind, resource) {
+			continue
+		}
+
+		for i := range resp.PolicyResponse.Rules {
+			if resp.PolicyResponse.Rules[i].Name == rule {
+				return resp, &resp.PolicyResponse.Rules[i]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func countGeneratedResources(engineResponses []*response.EngineResponse, policyNamespace, policy, rule, namespace, kind, resource string) int {
+	var count int
+	for _, resp := range engineResponses {
+		if !matchesResponse(resp, policyNamespace, policy, namespace, kind, resource) {
+			continue
+		}
+
+		for _, ruleResp := range resp.PolicyResponse.Rules {
+			if ruleResp.Name == rule && ruleResp.Type == response.Generation && len(ruleResp.GeneratedResource.Object) > 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func matchesResponse(resp *response.EngineResponse, policyNamespace, policy, namespace, kind, resource string) bool {
+	policyResp := resp.PolicyResponse
+	return policyResp.Policy.Name == policy &&
+		(policyNamespace == "" || policyResp.Policy.Namespace == policyNamespace) &&
+		(namespace == "" || policyResp.Resource.Namespace == namespace) &&
+		policyResp.Resource.Kind == kind &&
+		policyResp.Resource.Name == resource
+}
+
+// hasPatch checks if the expected operation is one of the JSON patches, the value is only compared when set
+func hasPatch(patches [][]byte, expected PatchOperation) (bool, error) {
+	var expectedValue interface{}
+	if expected.Value != nil {
+		// normalize the expected value to the types decoded from the patches
+		raw, err := json.Marshal(expected.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for patch %s %s: %v", expected.Op, expected.Path, err)
+		}
+
+		if err := json.Unmarshal(raw, &expectedValue); err != nil {
+			return false, fmt.Errorf("invalid value for patch %s %s: %v", expected.Op, expected.Path, err)
+		}
+	}
+
+	for _, patch := range patches {
+		var op PatchOperation
+		if err := json.Unmarshal(patch, &op); err != nil {
+			return false, fmt.Errorf("failed to decode patch %s: %v", string(patch), err)
+		}
+
+		if op.Op != expected.Op || op.Path != expected.Path {
+			continue
+		}
+
+		if expected.Value == nil || reflect.DeepEqual(op.Value, expectedValue) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newAssertionResponses() []*response.EngineResponse {
+	return []*response.EngineResponse{
+		{
+			PatchedResource: unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"team": "team-a"}},
+			}},
+			PolicyResponse: response.PolicyResponse{
+				Policy:   response.PolicySpec{Name: "add-labels"},
+				Resource: response.ResourceSpec{Kind: "Pod", Namespace: "default", Name: "nginx"},
+				Rules: []response.RuleResponse{
+					{
+						Name:    "add-team",
+						Type:    response.Mutation,
+						Message: "mutated Pod/nginx in namespace default",
+						Patches: [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/team","value":"team-a"}`)},
+					},
+					{
+						Name:              "generate-config",
+						Type:              response.Generation,
+						GeneratedResource: unstructured.Unstructured{Object: map[string]interface{}{"kind": "ConfigMap"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_checkAssertions(t *testing.T) {
+	one, two := 1, 2
+	testCases := []struct {
+		name string
+		test TestResults
+		rule string
+		err  string
+	}{
+		{name: "message", test: TestResults{Message: "mutated Pod/nginx in namespace default"}, rule: "add-team"},
+		{name: "message mismatch", test: TestResults{Message: "mutated"}, rule: "add-team", err: `expected message "mutated"`},
+		{name: "message pattern", test: TestResults{MessagePattern: "^mutated Pod/.* in namespace default$"}, rule: "add-team"},
+		{name: "message pattern mismatch", test: TestResults{MessagePattern: "^validated"}, rule: "add-team", err: "expected message matching"},
+		{name: "invalid message pattern", test: TestResults{MessagePattern: "(["}, rule: "add-team", err: "invalid message pattern"},
+		{name: "patch", test: TestResults{Patches: []PatchOperation{{Op: "add", Path: "/metadata/labels/team", Value: "team-a"}}}, rule: "add-team"},
+		{name: "patch without value", test: TestResults{Patches: []PatchOperation{{Op: "add", Path: "/metadata/labels/team"}}}, rule: "add-team"},
+		{name: "patch value mismatch", test: TestResults{Patches: []PatchOperation{{Op: "add", Path: "/metadata/labels/team", Value: "team-b"}}}, rule: "add-team", err: "patch add /metadata/labels/team not found"},
+		{name: "patched resource condition", test: TestResults{PatchedResourceCondition: "metadata.labels.team == 'team-a'"}, rule: "add-team"},
+		{name: "patched resource condition false", test: TestResults{PatchedResourceCondition: "metadata.labels.team == 'team-b'"}, rule: "add-team", err: "evaluated to false"},
+		{name: "generated resource count", test: TestResults{GeneratedResourceCount: &one}, rule: "generate-config"},
+		{name: "generated resource count mismatch", test: TestResults{GeneratedResourceCount: &two}, rule: "generate-config", err: "expected 2 generated resources, got 1"},
+		{name: "rule not found", test: TestResults{Message: "mutated"}, rule: "missing", err: "rule response not found"},
+		{name: "no assertions", test: TestResults{}, rule: "missing"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test.Kind = "Pod"
+			err := checkAssertions(tc.test, "", "add-labels", tc.rule, "nginx", newAssertionResponses())
+			if tc.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func Test_buildPolicyResults_Assertions(t *testing.T) {
+	one := 1
+	testCases := []struct {
+		name   string
+		test   TestResults
+		result policyreportv1alpha2.PolicyResult
+	}{
+		{name: "mutate with assertions", test: TestResults{Rule: "add-team", Patches: []PatchOperation{{Op: "add", Path: "/metadata/labels/team"}}}, result: policyreportv1alpha2.StatusPass},
+		{name: "mutate without assertions", test: TestResults{Rule: "add-team"}, result: policyreportv1alpha2.StatusFail},
+		{name: "generate with assertions", test: TestResults{Rule: "generate-config", GeneratedResourceCount: &one}, result: policyreportv1alpha2.StatusPass},
+		{name: "generate without assertions", test: TestResults{Rule: "generate-config"}, result: policyreportv1alpha2.StatusFail},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test.Policy = "add-labels"
+			tc.test.Kind = "Pod"
+			tc.test.Namespace = "default"
+			tc.test.Resource = "nginx"
+			tc.test.Result = policyreportv1alpha2.StatusPass
+			results, _ := buildPolicyResults(ioutil.Discard, newAssertionResponses(), []TestResults{tc.test}, nil, "", nil, false)
+			key := GetResultKeyAccordingToTestResults("", "add-labels", tc.test.Rule, "default", "Pod", "nginx")
+			assert.Equal(t, results[key].Result, tc.result)
+		})
+	}
+}
+
+func Test_printTestResult_AssertionReason(t *testing.T) {
+	test := TestResults{
+		Policy:    "add-labels",
+		Rule:      "add-team",
+		Kind:      "Pod",
+		Namespace: "default",
+		Resource:  "nginx",
+		Result:    policyreportv1alpha2.StatusPass,
+		Message:   "mutated",
+	}
+	responses := newAssertionResponses()
+	results, testResults := buildPolicyResults(ioutil.Discard, responses, []TestResults{test}, nil, "", nil, false)
+
+	var out bytes.Buffer
+	rc := &resultCounts{}
+	assert.NilError(t, printTestResult(&out, "assertions", results, testResults, responses, rc, &[]TestCase{}, false, true, tableOutput))
+	assert.Equal(t, rc.Fail, 1)
+	assert.Assert(t, bytes.Contains(out.Bytes(), []byte(`expected message "mutated"`)), out.String())
+}
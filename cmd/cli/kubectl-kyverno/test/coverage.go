@@ -0,0 +1,200 @@
+package test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kataras/tablewriter"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/lensesio/tableprinter"
+)
+
+// CoverageItem is a rule, or a branch of a rule, of a policy loaded by the tests
+type CoverageItem struct {
+	// Policy is the name of the policy, prefixed by its namespace for namespaced policies.
+	Policy string `json:"policy"`
+	// Rule is the name of the rule, including the autogen rules.
+	Rule string `json:"rule"`
+	// Branch is the anyPattern alternative (anyPattern[i]) or foreach entry (foreach[i]) of the rule, if any.
+	Branch string `json:"branch,omitempty"`
+	// Pass is true when a test exercised the rule or branch with a pass result.
+	Pass bool `json:"pass"`
+	// Fail is true when a test exercised the rule or branch with a fail result,
+	// it is not set when the rule or branch is not expected to fail.
+	Fail *bool `json:"fail,omitempty"`
+}
+
+func (c CoverageItem) name() string {
+	if c.Branch == "" {
+		return c.Rule
+	}
+	return c.Rule + "/" + c.Branch
+}
+
+// coverageReport is the coverage of the policies by the tests, as the share of the expected pass and fail results exercised
+type coverageReport struct {
+	Percentage float64        `json:"percentage"`
+	Covered    int            `json:"covered"`
+	Total      int            `json:"total"`
+	Rules      []CoverageItem `json:"rules"`
+}
+
+// coverage collects the rules of the policies loaded by the tests and the results they produced
+type coverage struct {
+	items []*CoverageItem
+	index map[string]*CoverageItem
+}
+
+func newCoverage() *coverage {
+	return &coverage{index: map[string]*CoverageItem{}}
+}
+
+func coverageKey(policy, rule, branch string) string {
+	return policy + "/" + rule + "/" + branch
+}
+
+func coveragePolicyName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func (c *coverage) add(policy, rule, branch string, canFail bool) {
+	key := coverageKey(policy, rule, branch)
+	if _, ok := c.index[key]; ok {
+		return
+	}
+	item := &CoverageItem{Policy: policy, Rule: rule, Branch: branch}
+	if canFail {
+		item.Fail = new(bool)
+	}
+	c.items = append(c.items, item)
+	c.index[key] = item
+}
+
+// addPolicies adds the rules of the policies, it must be called before the rules are filtered by the tests
+func (c *coverage) addPolicies(policies []kyvernov1.PolicyInterface) {
+	for _, p := range policies {
+		policy := coveragePolicyName(p.GetNamespace(), p.GetName())
+		for _, rule := range autogen.ComputeRules(p) {
+			switch {
+			case rule.HasValidate():
+				c.add(policy, rule.Name, "", true)
+				// all alternatives fail together, only their pass results are tracked
+				if anyPatterns, err := rule.Validation.DeserializeAnyPattern(); err == nil {
+					for i := range anyPatterns {
+						c.add(policy, rule.Name, fmt.Sprintf("anyPattern[%d]", i), false)
+					}
+				}
+				for i := range rule.Validation.ForEachValidation {
+					c.add(policy, rule.Name, fmt.Sprintf("foreach[%d]", i), true)
+				}
+			case rule.HasVerifyImages():
+				c.add(policy, rule.Name, "", true)
+			case rule.HasMutate():
+				c.add(policy, rule.Name, "", false)
+				for i := range rule.Mutation.ForEachMutation {
+					c.add(policy, rule.Name, fmt.Sprintf("foreach[%d]", i), false)
+				}
+			default:
+				c.add(policy, rule.Name, "", false)
+			}
+		}
+	}
+}
+
+// addResponses marks the rules exercised by the engine responses
+func (c *coverage) addResponses(engineResponses []*response.EngineResponse) {
+	for _, resp := range engineResponses {
+		policy := coveragePolicyName(resp.PolicyResponse.Policy.Namespace, resp.PolicyResponse.Policy.Name)
+		for _, rule := range resp.PolicyResponse.Rules {
+			c.mark(policy, rule.Name, "", rule.Status)
+		}
+	}
+}
+
+// addBranches marks the branches exercised by a rule, it is the branch recorder of the policy contexts
+func (c *coverage) addBranches(p kyvernov1.PolicyInterface, rule string, branches []string, status response.RuleStatus) {
+	policy := coveragePolicyName(p.GetNamespace(), p.GetName())
+	for _, branch := range branches {
+		c.mark(policy, rule, branch, status)
+	}
+}
+
+func (c *coverage) mark(policy, rule, branch string, status response.RuleStatus) {
+	item, ok := c.index[coverageKey(policy, rule, branch)]
+	if !ok {
+		return
+	}
+	switch status {
+	case response.RuleStatusPass:
+		item.Pass = true
+	case response.RuleStatusFail:
+		if item.Fail != nil {
+			*item.Fail = true
+		}
+	}
+}
+
+func (c *coverage) report() *coverageReport {
+	report := &coverageReport{Rules: []CoverageItem{}}
+	for _, item := range c.items {
+		report.Total++
+		if item.Pass {
+			report.Covered++
+		}
+		if item.Fail != nil {
+			report.Total++
+			if *item.Fail {
+				report.Covered++
+			}
+		}
+		report.Rules = append(report.Rules, *item)
+	}
+	if report.Total > 0 {
+		report.Percentage = float64(report.Covered) * 100 / float64(report.Total)
+	}
+	return report
+}
+
+type coverageTable struct {
+	Policy string `header:"policy"`
+	Rule   string `header:"rule"`
+	Pass   string `header:"pass"`
+	Fail   string `header:"fail"`
+}
+
+func coverageResult(covered bool) string {
+	if covered {
+		return "Yes"
+	}
+	return "No"
+}
+
+func printCoverage(out io.Writer, report *coverageReport, removeColor bool) {
+	table := []coverageTable{}
+	for _, item := range report.Rules {
+		row := coverageTable{Policy: item.Policy, Rule: item.name(), Pass: coverageResult(item.Pass), Fail: "-"}
+		if item.Fail != nil {
+			row.Fail = coverageResult(*item.Fail)
+		}
+		table = append(table, row)
+	}
+
+	printer := tableprinter.New(out)
+	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
+	printer.CenterSeparator = "│"
+	printer.ColumnSeparator = "│"
+	printer.RowSeparator = "─"
+	printer.RowCharLimit = 300
+	if !removeColor {
+		printer.HeaderBgColor = tablewriter.BgBlackColor
+		printer.HeaderFgColor = tablewriter.FgGreenColor
+	}
+	fmt.Fprintf(out, "Policy Coverage : \n")
+	printer.Print(table)
+	fmt.Fprintf(out, "\nCoverage: %.2f%% (%d/%d)\n\n", report.Percentage, report.Covered, report.Total)
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+)
+
+var coveragePolicy = []byte(`{
+	"apiVersion": "kyverno.io/v1",
+	"kind": "ClusterPolicy",
+	"metadata": {"name": "check-labels"},
+	"spec": {
+		"rules": [
+			{
+				"name": "any-label",
+				"match": {"resources": {"kinds": ["Namespace"]}},
+				"validate": {
+					"anyPattern": [
+						{"metadata": {"labels": {"team": "?*"}}},
+						{"metadata": {"labels": {"app": "?*"}}}
+					]
+				}
+			},
+			{
+				"name": "add-label",
+				"match": {"resources": {"kinds": ["Namespace"]}},
+				"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"managed": "true"}}}}
+			}
+		]
+	}
+}`)
+
+func Test_coverage(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(coveragePolicy, &policy))
+
+	c := newCoverage()
+	c.addPolicies([]kyvernov1.PolicyInterface{&policy})
+	// policies loaded by several test files are only added once
+	c.addPolicies([]kyvernov1.PolicyInterface{&policy})
+
+	report := c.report()
+	assert.Equal(t, report.Total, 5)
+	assert.Equal(t, report.Covered, 0)
+	assert.Equal(t, report.Percentage, float64(0))
+
+	c.addResponses([]*response.EngineResponse{
+		{
+			PolicyResponse: response.PolicyResponse{
+				Policy: response.PolicySpec{Name: "check-labels"},
+				Rules: []response.RuleResponse{
+					{Name: "any-label", Status: response.RuleStatusPass},
+					{Name: "add-label", Status: response.RuleStatusPass},
+				},
+			},
+		},
+		{
+			PolicyResponse: response.PolicyResponse{
+				Policy: response.PolicySpec{Name: "unknown"},
+				Rules:  []response.RuleResponse{{Name: "any-label", Status: response.RuleStatusFail}},
+			},
+		},
+	})
+	c.addBranches(&policy, "any-label", []string{"anyPattern[1]"}, response.RuleStatusPass)
+
+	report = c.report()
+	assert.Equal(t, report.Total, 5)
+	assert.Equal(t, report.Covered, 3)
+	assert.Equal(t, report.Percentage, float64(60))
+	assert.Equal(t, len(report.Rules), 4)
+	assert.Equal(t, report.Rules[0].name(), "any-label")
+	assert.Equal(t, report.Rules[0].Pass, true)
+	assert.Equal(t, *report.Rules[0].Fail, false)
+	assert.Equal(t, report.Rules[1].name(), "any-label/anyPattern[0]")
+	assert.Equal(t, report.Rules[1].Pass, false)
+	assert.Assert(t, report.Rules[1].Fail == nil)
+	assert.Equal(t, report.Rules[2].name(), "any-label/anyPattern[1]")
+	assert.Equal(t, report.Rules[2].Pass, true)
+	assert.Equal(t, report.Rules[3].name(), "add-label")
+	assert.Assert(t, report.Rules[3].Fail == nil)
+}
@@ -0,0 +1,250 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+const (
+	tableOutput = "table"
+	jsonOutput  = "json"
+	junitOutput = "junit"
+	tapOutput   = "tap"
+)
+
+var outputFormats = []string{tableOutput, jsonOutput, junitOutput, tapOutput}
+
+func validateOutputFormat(format string) error {
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %s, expected one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+// TestCase is the outcome of a single test result, as reported by the json, junit and tap output formats
+type TestCase struct {
+	// Test is the name of the test the result belongs to.
+	Test string `json:"test"`
+	// Policy is the name of the policy.
+	Policy string `json:"policy"`
+	// Rule is the name of the rule in the policy.
+	Rule string `json:"rule"`
+	// Resource is the resource formatted as namespace/kind/name.
+	Resource string `json:"resource"`
+	// Expected is the result expected by the test.
+	Expected policyreportv1alpha2.PolicyResult `json:"expected"`
+	// Actual is the result produced by the policy, empty if no result was found.
+	Actual policyreportv1alpha2.PolicyResult `json:"actual,omitempty"`
+	// Passed is true when the actual result matches the expected result.
+	Passed bool `json:"passed"`
+	// Message is the failure message, or the message of the policy result.
+	Message string `json:"message,omitempty"`
+}
+
+func (tc TestCase) name() string {
+	return fmt.Sprintf("%s/%s/%s", tc.Policy, tc.Rule, tc.Resource)
+}
+
+func newTestCase(test string, result TestResults, policy, resource string, expected policyreportv1alpha2.PolicyResult, actual *policyreportv1alpha2.PolicyReportResult) TestCase {
+	tc := TestCase{
+		Test:     test,
+		Policy:   policy,
+		Rule:     result.Rule,
+		Resource: resource,
+		Expected: expected,
+	}
+	if actual == nil {
+		tc.Message = "result not found"
+		return tc
+	}
+	tc.Actual = actual.Result
+	tc.Passed = actual.Result == expected
+	if tc.Passed {
+		tc.Message = actual.Message
+	} else if actual.Message != "" {
+		tc.Message = fmt.Sprintf("expected %s, got %s: %s", expected, actual.Result, actual.Message)
+	} else {
+		tc.Message = fmt.Sprintf("expected %s, got %s", expected, actual.Result)
+	}
+	return tc
+}
+
+// withAssertionError marks the test case as failed when an assertion of the test failed
+func (tc TestCase) withAssertionError(err error) TestCase {
+	if err != nil {
+		tc.Passed = false
+		tc.Message = err.Error()
+	}
+	return tc
+}
+
+// expectedResult returns the result expected by a test, falling back to the deprecated status field
+func expectedResult(result TestResults) policyreportv1alpha2.PolicyResult {
+	if result.Result == "" {
+		return result.Status
+	}
+	return result.Result
+}
+
+func printTestReport(w io.Writer, format string, testCases []TestCase, rc *resultCounts, coverage *coverageReport) error {
+	switch format {
+	case jsonOutput:
+		return printJSONReport(w, testCases, rc, coverage)
+	case junitOutput:
+		return printJUnitReport(w, testCases, rc)
+	case tapOutput:
+		return printTAPReport(w, testCases)
+	default:
+		return nil
+	}
+}
+
+type jsonReport struct {
+	Summary  jsonSummary     `json:"summary"`
+	Tests    []TestCase      `json:"tests"`
+	Coverage *coverageReport `json:"coverage,omitempty"`
+}
+
+type jsonSummary struct {
+	Pass int `json:"pass"`
+	Fail int `json:"fail"`
+	Skip int `json:"skip"`
+}
+
+func printJSONReport(w io.Writer, testCases []TestCase, rc *resultCounts, coverage *coverageReport) error {
+	report := jsonReport{
+		Summary:  jsonSummary{Pass: rc.Pass, Fail: rc.Fail, Skip: rc.Skip},
+		Tests:    testCases,
+		Coverage: coverage,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	Properties []junitProperty  `xml:"properties>property,omitempty"`
+	Failure    *junitFailure    `xml:"failure,omitempty"`
+	Skipped    *junitSkipped    `xml:"skipped,omitempty"`
+	SystemOut  *junitSystemText `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitSystemText struct {
+	Text string `xml:",chardata"`
+}
+
+func printJUnitReport(w io.Writer, testCases []TestCase, rc *resultCounts) error {
+	report := junitTestSuites{
+		Name:     "kyverno",
+		Tests:    len(testCases),
+		Failures: rc.Fail,
+		Skipped:  rc.Skip,
+	}
+	suites := map[string]int{}
+	for _, tc := range testCases {
+		i, ok := suites[tc.Test]
+		if !ok {
+			i = len(report.Suites)
+			suites[tc.Test] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: tc.Test})
+		}
+		suite := &report.Suites[i]
+		testCase := junitTestCase{
+			Name:      tc.name(),
+			Classname: tc.Policy,
+			Properties: []junitProperty{
+				{Name: "policy", Value: tc.Policy},
+				{Name: "rule", Value: tc.Rule},
+				{Name: "resource", Value: tc.Resource},
+				{Name: "expected", Value: string(tc.Expected)},
+				{Name: "actual", Value: string(tc.Actual)},
+			},
+		}
+		if !tc.Passed {
+			testCase.Failure = &junitFailure{Message: tc.Message, Type: "ResultMismatch", Text: tc.Message}
+			suite.Failures++
+		} else if tc.Actual == policyreportv1alpha2.StatusSkip {
+			// the rules that are expected to be skipped are reported as skipped test cases
+			testCase.Skipped = &junitSkipped{Message: tc.Message}
+			suite.Skipped++
+		} else if tc.Message != "" {
+			testCase.SystemOut = &junitSystemText{Text: tc.Message}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func printTAPReport(w io.Writer, testCases []TestCase) error {
+	var sb strings.Builder
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", len(testCases)))
+	for i, tc := range testCases {
+		status := "ok"
+		if !tc.Passed {
+			status = "not ok"
+		}
+		sb.WriteString(fmt.Sprintf("%s %d - %s\n", status, i+1, tc.name()))
+		if !tc.Passed {
+			sb.WriteString("  ---\n")
+			sb.WriteString(fmt.Sprintf("  test: %q\n", tc.Test))
+			sb.WriteString(fmt.Sprintf("  expected: %q\n", tc.Expected))
+			sb.WriteString(fmt.Sprintf("  actual: %q\n", tc.Actual))
+			sb.WriteString(fmt.Sprintf("  message: %q\n", tc.Message))
+			sb.WriteString("  ...\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func newTestCases() []TestCase {
+	result := TestResults{Rule: "require-image-tag"}
+	return []TestCase{
+		newTestCase("test-simple", result, "disallow-latest-tag", "default/Pod/pass", policyreportv1alpha2.StatusPass,
+			&policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusPass}),
+		newTestCase("test-simple", result, "disallow-latest-tag", "default/Pod/fail", policyreportv1alpha2.StatusPass,
+			&policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusFail, Message: "an image tag is required"}),
+		newTestCase("test-other", result, "disallow-latest-tag", "default/Pod/missing", policyreportv1alpha2.StatusSkip, nil),
+	}
+}
+
+func Test_newTestCase(t *testing.T) {
+	testCases := newTestCases()
+	assert.Equal(t, testCases[0].Passed, true)
+	assert.Equal(t, testCases[1].Passed, false)
+	assert.Equal(t, testCases[1].Actual, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusFail))
+	assert.Equal(t, testCases[1].Message, "expected pass, got fail: an image tag is required")
+	assert.Equal(t, testCases[2].Passed, false)
+	assert.Equal(t, testCases[2].Message, "result not found")
+}
+
+func Test_printJSONReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := printTestReport(&buf, jsonOutput, newTestCases(), &resultCounts{Pass: 1, Fail: 2}, nil)
+	assert.NilError(t, err)
+
+	var report jsonReport
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.DeepEqual(t, report.Summary, jsonSummary{Pass: 1, Fail: 2})
+	assert.Equal(t, len(report.Tests), 3)
+	assert.Equal(t, report.Tests[1].Resource, "default/Pod/fail")
+}
+
+func Test_printJUnitReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := printTestReport(&buf, junitOutput, newTestCases(), &resultCounts{Pass: 1, Fail: 2}, nil)
+	assert.NilError(t, err)
+
+	var report junitTestSuites
+	assert.NilError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, report.Tests, 3)
+	assert.Equal(t, report.Failures, 2)
+	assert.Equal(t, len(report.Suites), 2)
+	assert.Equal(t, report.Suites[0].Name, "test-simple")
+	assert.Equal(t, report.Suites[0].Tests, 2)
+	assert.Equal(t, report.Suites[0].Failures, 1)
+	assert.Equal(t, report.Suites[0].Cases[1].Name, "disallow-latest-tag/require-image-tag/default/Pod/fail")
+	assert.Assert(t, report.Suites[0].Cases[0].Failure == nil)
+	assert.Equal(t, report.Suites[0].Cases[1].Failure.Message, "expected pass, got fail: an image tag is required")
+}
+
+func Test_printJUnitReport_Skipped(t *testing.T) {
+	result := TestResults{Rule: "require-image-tag"}
+	testCases := []TestCase{
+		newTestCase("test-skip", result, "disallow-latest-tag", "default/Pod/skip", policyreportv1alpha2.StatusSkip,
+			&policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusSkip, Message: "rule skipped"}),
+		newTestCase("test-skip", result, "disallow-latest-tag", "default/Pod/fail", policyreportv1alpha2.StatusSkip,
+			&policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusFail}),
+	}
+
+	var buf bytes.Buffer
+	assert.NilError(t, printTestReport(&buf, junitOutput, testCases, &resultCounts{Skip: 1, Fail: 1}, nil))
+	assert.Assert(t, strings.Contains(buf.String(), `<skipped message="rule skipped"></skipped>`), buf.String())
+
+	var report junitTestSuites
+	assert.NilError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, report.Skipped, 1)
+	assert.Equal(t, report.Suites[0].Skipped, 1)
+	assert.Equal(t, report.Suites[0].Failures, 1)
+	assert.Equal(t, report.Suites[0].Cases[0].Skipped.Message, "rule skipped")
+	assert.Assert(t, report.Suites[0].Cases[0].Failure == nil)
+	assert.Assert(t, report.Suites[0].Cases[1].Skipped == nil)
+}
+
+func Test_printTAPReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := printTestReport(&buf, tapOutput, newTestCases(), &resultCounts{Pass: 1, Fail: 2}, nil)
+	assert.NilError(t, err)
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, lines[0], "TAP version 13")
+	assert.Equal(t, lines[1], "1..3")
+	assert.Equal(t, lines[2], "ok 1 - disallow-latest-tag/require-image-tag/default/Pod/pass")
+	assert.Equal(t, lines[3], "not ok 2 - disallow-latest-tag/require-image-tag/default/Pod/fail")
+	assert.Assert(t, strings.Contains(buf.String(), `  message: "result not found"`))
+}
+
+func Test_validateOutputFormat(t *testing.T) {
+	for _, format := range []string{"table", "json", "junit", "tap"} {
+		assert.NilError(t, validateOutputFormat(format))
+	}
+	assert.ErrorContains(t, validateOutputFormat("xml"), "invalid output format xml")
+}
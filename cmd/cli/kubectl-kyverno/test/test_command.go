@@ -3,6 +3,7 @@ package test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	"github.com/kyverno/kyverno/pkg/policyreport"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	util "github.com/kyverno/kyverno/pkg/utils"
 	"github.com/lensesio/tableprinter"
 	"github.com/spf13/cobra"
@@ -67,6 +69,12 @@ applying 1 policy to 2 resources...
 # Test a local folder containing test cases.
 kyverno test .
 
+# Run the tests of a local folder again each time one of their files changes.
+kyverno test . --watch
+
+# Report the policy coverage and fail when less than 80% of the expected rule results are exercised.
+kyverno test . --min-coverage 80
+
 Executing limit-containers-per-pod...
 applying 1 policy to 4 resources... 
 
@@ -95,14 +103,19 @@ applying 1 policy to 1 resource...
 
 Test Summary: 1 tests passed and 0 tests failed
 
+# Write the test results of a local folder as a JUnit report, progress messages are printed to stderr.
+kyverno test . --output-format junit > kyverno-test-results.xml
+
 
 
 **TEST FILE STRUCTURE**:
 
-The kyverno-test.yaml has four parts:
+The kyverno-test.yaml has six parts:
 	"policies"   --> List of policies which are applied.
 	"resources"  --> List of resources on which the policies are applied.
 	"variables"  --> Variable file path containing variables referenced in the policy (OPTIONAL).
+	"context"    --> Objects and API responses returned to the configMap and apiCall context entries (OPTIONAL).
+	"registry"   --> OCI image layout serving the images to the verifyImages rules and imageRegistry context entries (OPTIONAL).
 	"results"    --> List of results expected after applying the policies to the resources.
 
 ** TEST FILE FORMAT**:
@@ -115,6 +128,14 @@ resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
 variables: <variable_file> (OPTIONAL)
+context: (OPTIONAL)
+  resources:
+  - <path/to/configmaps.yaml>
+  apiCalls:
+  - urlPath: <url_path> (For example /api/v1/namespaces/default/pods)
+    response: <api_response> (The JMESPath of the context entry is applied to the response)
+registry: (OPTIONAL)
+  layout: <path/to/oci/layout> (Manifests are served with the image reference of their org.opencontainers.image.ref.name annotation)
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
   rule: <name>
@@ -123,6 +144,14 @@ results:
   kind: <name>
   patchedResource: <path/to/patched/resource.yaml> (For mutate policies/rules only)
   result: <pass|fail|skip>
+  message: <rule_message> (OPTIONAL, the rule message must be equal)
+  messagePattern: <regular_expression> (OPTIONAL, the rule message must match)
+  patches: (OPTIONAL, for mutate rules, the JSON patch operations must be part of the rule patches)
+  - op: add
+    path: /metadata/labels/foo
+    value: bar
+  patchedResourceCondition: <jmespath> (OPTIONAL, for mutate rules, must evaluate to true on the patched resource)
+  generatedResourceCount: <count> (OPTIONAL, for generate rules)
 
 **VARIABLES FILE FORMAT**:
 
@@ -148,6 +177,10 @@ pass  --> The resource is either validated by the policy or, if a mutation, equa
 fail  --> The resource fails validation or the patched resource generated by Kyverno is not equal to the input resource provided by the user.
 skip  --> The rule is not applied.
 
+**POLICY COVERAGE**:
+
+With --coverage, each rule of the loaded policies (including the autogen and autogen-cronjob rules), anyPattern alternative and foreach entry is reported with whether the tests exercised it with a pass and a fail result. Fail results are not expected for mutate and generate rules, nor for single anyPattern alternatives. The coverage is the percentage of expected results exercised.
+
 For more information visit https://kyverno.io/docs/kyverno-cli/#test
 `
 
@@ -156,8 +189,9 @@ func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
 	var testFile []byte
-	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor bool
+	var fileName, gitBranch, outputFormat string
+	var registryAccess, failOnly, removeColor, showCoverage, watchFiles bool
+	var minCoverage float64
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -165,6 +199,7 @@ func Command() *cobra.Command {
 		Long:    longHelp,
 		Example: exampleHelp,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
+			out := cmd.OutOrStdout()
 			defer func() {
 				if err != nil {
 					if !sanitizederror.IsErrorSanitized(err) {
@@ -185,6 +220,14 @@ resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
 variables: <variable_file> (OPTIONAL)
+context: (OPTIONAL)
+  resources:
+  - <path/to/configmaps.yaml>
+  apiCalls:
+  - urlPath: <url_path> (For example /api/v1/namespaces/default/pods)
+    response: <api_response> (The JMESPath of the context entry is applied to the response)
+registry: (OPTIONAL)
+  layout: <path/to/oci/layout> (Manifests are served with the image reference of their org.opencontainers.image.ref.name annotation)
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
   rule: <name>
@@ -193,7 +236,7 @@ results:
   kind: <name>
   patchedResource: <path/to/patched/resource.yaml>
   result: <pass|fail|skip>`)
-				fmt.Println(string(testFile))
+				fmt.Fprintln(out, string(testFile))
 				return nil
 			}
 			if vStatus {
@@ -205,6 +248,14 @@ resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
 variables: <variable_file> (OPTIONAL)
+context: (OPTIONAL)
+  resources:
+  - <path/to/configmaps.yaml>
+  apiCalls:
+  - urlPath: <url_path> (For example /api/v1/namespaces/default/pods)
+    response: <api_response> (The JMESPath of the context entry is applied to the response)
+registry: (OPTIONAL)
+  layout: <path/to/oci/layout> (Manifests are served with the image reference of their org.opencontainers.image.ref.name annotation)
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
   rule: <name>
@@ -212,11 +263,23 @@ results:
   namespace: <name> (OPTIONAL)
   kind: <name>
   result: <pass|fail|skip>`)
-				fmt.Println(string(testFile))
+				fmt.Fprintln(out, string(testFile))
 				return nil
 			}
+			if err := validateOutputFormat(outputFormat); err != nil {
+				return sanitizederror.NewWithError("invalid output format", err)
+			}
+			if minCoverage < 0 || minCoverage > 100 {
+				return sanitizederror.NewWithError("invalid minimum coverage", fmt.Errorf("expected a percentage between 0 and 100, got %v", minCoverage))
+			}
 			store.SetRegistryAccess(registryAccess)
-			_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+			if watchFiles {
+				if outputFormat != tableOutput || showCoverage || minCoverage > 0 {
+					return sanitizederror.New("--watch can only be used with the table output format and without coverage")
+				}
+				return watchTests(out, dirPath, fileName, testCase, failOnly, removeColor)
+			}
+			_, err = testCommandExecute(out, cmd.ErrOrStderr(), dirPath, fileName, gitBranch, testCase, failOnly, removeColor, outputFormat, showCoverage || minCoverage > 0, minCoverage)
 			if err != nil {
 				log.Log.V(3).Info("a directory is required")
 				return err
@@ -233,6 +296,10 @@ results:
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "", tableOutput, "Output format of the test results, one of table, json, junit or tap. Other formats print the progress messages to stderr")
+	cmd.Flags().BoolVarP(&showCoverage, "coverage", "", false, "Report the rules, autogen rules, anyPattern alternatives and foreach entries of the policies exercised with a pass and a fail result")
+	cmd.Flags().BoolVarP(&watchFiles, "watch", "w", false, "Run the tests again when the test files or the policies, resources, variables and other files they reference change")
+	cmd.Flags().Float64VarP(&minCoverage, "min-coverage", "", 0, "Fail when the policy coverage percentage is below this value, implies --coverage")
 	return cmd
 }
 
@@ -243,6 +310,25 @@ type Test struct {
 	Variables string        `json:"variables"`
 	UserInfo  string        `json:"userinfo"`
 	Results   []TestResults `json:"results"`
+	// Context declares the data returned to the apiCall and configMap context entries of the policies.
+	Context *TestContext `json:"context"`
+	// Registry declares the images returned to the image verification rules and imageRegistry context entries.
+	Registry *TestRegistry `json:"registry"`
+}
+
+type TestContext struct {
+	// Resources gives the files with the Kubernetes objects, like config maps,
+	// returned to the configMap context entries.
+	Resources []string `json:"resources"`
+	// APICalls gives the responses returned to the apiCall context entries, keyed by URL path.
+	APICalls []store.APICall `json:"apiCalls"`
+}
+
+type TestRegistry struct {
+	// Layout is the path of an OCI image layout directory holding the images, signatures and attestations.
+	// Each manifest of the layout is served with the image reference of its org.opencontainers.image.ref.name
+	// annotation, e.g. ghcr.io/kyverno/test-verify-image:signed or its cosign signature tag.
+	Layout string `json:"layout"`
 }
 
 type TestResults struct {
@@ -276,6 +362,17 @@ type TestResults struct {
 	// CloneSourceResource takes the resource configuration file in yaml format
 	// from the user which is meant to be cloned by the generate rule.
 	CloneSourceResource string `json:"cloneSourceResource"`
+	// Message asserts the message of the rule response.
+	Message string `json:"message"`
+	// MessagePattern asserts the message of the rule response with a regular expression.
+	MessagePattern string `json:"messagePattern"`
+	// Patches asserts that the JSON patch operations are part of the patches of the mutate rule.
+	Patches []PatchOperation `json:"patches"`
+	// PatchedResourceCondition asserts that the JMESPath expression evaluates to true
+	// on the resource patched by the policy.
+	PatchedResourceCondition string `json:"patchedResourceCondition"`
+	// GeneratedResourceCount asserts the number of resources generated by the generate rule.
+	GeneratedResourceCount *int `json:"generatedResourceCount"`
 }
 
 type ReportResult struct {
@@ -294,6 +391,7 @@ type Table struct {
 	Rule     string `header:"rule"`
 	Resource string `header:"resource"`
 	Result   string `header:"result"`
+	Reason   string `header:"reason"`
 }
 type Policy struct {
 	Name      string     `json:"name"`
@@ -319,25 +417,18 @@ type testFilter struct {
 
 var ftable = []Table{}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
-	var errors []error
-	fs := memfs.New()
-	rc = &resultCounts{}
-	var testYamlCount int
+// newTestFilter parses the test case selector, all test cases are selected when it is empty or invalid
+func newTestFilter(out io.Writer, testCase string) *testFilter {
 	tf := &testFilter{
 		enabled: true,
 	}
 
-	if len(dirPath) == 0 {
-		return rc, sanitizederror.NewWithError("a directory is required", err)
-	}
-
 	if len(testCase) != 0 {
 		parameters := map[string]string{"policy": "", "rule": "", "resource": ""}
 
 		for _, t := range strings.Split(testCase, ",") {
 			if !strings.Contains(t, "=") {
-				fmt.Printf("\n Invalid test-case-selector argument. Selecting all test cases. \n")
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument. Selecting all test cases. \n")
 				tf.enabled = false
 				break
 			}
@@ -347,7 +438,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 			_, ok := parameters[key]
 			if !ok {
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				tf.enabled = false
 				break
 			}
@@ -362,6 +453,28 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		tf.enabled = false
 	}
 
+	return tf
+}
+
+func testCommandExecute(stdout, stderr io.Writer, dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool, outputFormat string, showCoverage bool, minCoverage float64) (rc *resultCounts, err error) {
+	var errors []error
+	// with structured output formats, stdout only contains the test report
+	out := stdout
+	if outputFormat != tableOutput {
+		out = stderr
+	}
+	fs := memfs.New()
+	rc = &resultCounts{}
+	var testYamlCount int
+	if len(dirPath) == 0 {
+		return rc, sanitizederror.NewWithError("a directory is required", err)
+	}
+
+	tf := newTestFilter(out, testCase)
+	policyCoverage := newCoverage()
+	// the results of all tests for the structured output formats
+	testCases := []TestCase{}
+
 	openAPIController, err := openapi.NewOpenAPIController()
 	if err != nil {
 		return rc, fmt.Errorf("unable to create open api controller, %w", err)
@@ -375,7 +488,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 
@@ -407,7 +520,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		_, cloneErr := clone(repoURL, fs, gitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -439,48 +552,95 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openAPIController, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(out, fs, policyBytes, true, policyresoucePath, rc, openAPIController, tf, policyCoverage, &testCases, failOnly, removeColor, outputFormat); err != nil {
+					exitOnLoadError(err)
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
 		}
 
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openAPIController, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(out, fs, path, fileName, rc, &testFiles, openAPIController, tf, policyCoverage, &testCases, failOnly, removeColor, outputFormat)
 
 		if testFiles == 0 {
-			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+			fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
 		}
 	}
 
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("test errors: \n")
+		fmt.Fprintf(out, "test errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
 		}
 	}
 
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+	fmt.Fprintf(out, "\n")
+
+	var coverage *coverageReport
+	if showCoverage {
+		coverage = policyCoverage.report()
+		printCoverage(out, coverage, removeColor)
+	}
+
+	belowMinCoverage := coverage != nil && coverage.Percentage < minCoverage
+	if belowMinCoverage {
+		fmt.Fprintf(out, "Coverage %.2f%% is below the minimum coverage %.2f%%\n\n", coverage.Percentage, minCoverage)
+	}
+
+	if outputFormat != tableOutput {
+		reported := testCases
+		if failOnly {
+			reported = []TestCase{}
+			for _, tc := range testCases {
+				if !tc.Passed {
+					reported = append(reported, tc)
+				}
+			}
+		}
+		if err := printTestReport(stdout, outputFormat, reported, rc, coverage); err != nil {
+			return rc, sanitizederror.NewWithError("failed to print test report", err)
+		}
+		if rc.Fail > 0 || belowMinCoverage {
+			os.Exit(1)
+		}
+		os.Exit(0)
 	}
-	fmt.Printf("\n")
 
 	if rc.Fail > 0 && !failOnly {
-		printFailedTestResult()
+		printFailedTestResult(out)
+		os.Exit(1)
+	}
+	if belowMinCoverage {
 		os.Exit(1)
 	}
 	os.Exit(0)
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openAPIController *openapi.Controller, tf *testFilter, failOnly, removeColor bool) []error {
+// loadError is returned when the files referenced by a test file can not be loaded
+type loadError struct {
+	error
+}
+
+// exitOnLoadError exits when the files referenced by a test file can not be loaded,
+// the watch mode reports the error and keeps watching instead
+func exitOnLoadError(err error) {
+	if _, ok := err.(loadError); ok {
+		os.Exit(1)
+	}
+}
+
+func getLocalDirTestFiles(out io.Writer, fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openAPIController *openapi.Controller, tf *testFilter, policyCoverage *coverage, testCases *[]TestCase, failOnly, removeColor bool, outputFormat string) []error {
 	var errors []error
 
 	files, err := ioutil.ReadDir(path)
@@ -489,7 +649,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openAPIController, tf, failOnly, removeColor)
+			getLocalDirTestFiles(out, fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openAPIController, tf, policyCoverage, testCases, failOnly, removeColor, outputFormat)
 			continue
 		}
 		if file.Name() == fileName {
@@ -505,7 +665,8 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openAPIController, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(out, fs, valuesBytes, false, path, rc, openAPIController, tf, policyCoverage, testCases, failOnly, removeColor, outputFormat); err != nil {
+				exitOnLoadError(err)
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -514,7 +675,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	return errors
 }
 
-func buildPolicyResults(engineResponses []*response.EngineResponse, testResults []TestResults, infos []policyreport.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []TestResults) {
+func buildPolicyResults(out io.Writer, engineResponses []*response.EngineResponse, testResults []TestResults, infos []policyreport.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []TestResults) {
 	results := make(map[string]policyreportv1alpha2.PolicyReportResult)
 	now := metav1.Timestamp{Seconds: time.Now().Unix()}
 
@@ -588,7 +749,9 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 								}
 							}
 
-							patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+							if test.PatchedResource != "" || !test.hasAssertions() {
+								patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+							}
 							if _, ok := results[resultsKey]; !ok {
 								results[resultsKey] = result
 							}
@@ -621,7 +784,10 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 						}
 					}
 
-					patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+					// the patched resource is only compared when given, the assertions are checked when printing the results
+					if test.PatchedResource != "" || !test.hasAssertions() {
+						patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+					}
 					if _, ok := results[resultsKey]; !ok {
 						results[resultsKey] = result
 					}
@@ -649,10 +815,13 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 						result.Result = policyreportv1alpha2.StatusSkip
 					} else if rule.Status == response.RuleStatusError {
 						result.Result = policyreportv1alpha2.StatusError
+					} else if test.GeneratedResource == "" && test.hasAssertions() {
+						// the generated resource is only compared when given, the assertions are checked when printing the results
+						result.Result = policyreportv1alpha2.StatusPass
 					} else {
 						var x string
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
+						x = getAndCompareResource(out, test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
 						}
@@ -685,9 +854,10 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 					result.Result = policyreportv1alpha2.StatusError
 				} else {
 					var x string
+					result.Result = policyreportv1alpha2.StatusPass
 					for _, path := range patchedResourcePath {
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
+						x = getAndCompareResource(out, path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
 							break
@@ -772,7 +942,7 @@ func getUserDefinedPolicyNameAndNamespace(policyName string) (string, string) {
 
 // getAndCompareResource --> Get the patchedResource or generatedResource from the path provided by user
 // And compare this resource with engine generated resource.
-func getAndCompareResource(path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
+func getAndCompareResource(out io.Writer, path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
 	var status string
 	resourceType := "patchedResource"
 	if isGenerate {
@@ -781,7 +951,7 @@ func getAndCompareResource(path string, engineResource unstructured.Unstructured
 
 	userResource, err := common.GetResourceFromPath(fs, path, isGit, policyResourcePath, resourceType)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		return ""
 	}
 	matched, err := generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
@@ -819,7 +989,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openAPIController *openapi.Controller, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(out io.Writer, fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openAPIController *openapi.Controller, tf *testFilter, policyCoverage *coverage, testCases *[]TestCase, failOnly, removeColor bool, outputFormat string) (err error) {
 	engineResponses := make([]*response.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &Test{}
@@ -845,7 +1015,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return nil
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
@@ -864,12 +1034,43 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	if userInfoFile != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
-			os.Exit(1)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
+			return loadError{sanitizederror.NewWithError("failed to load request info", err)}
 		}
 		store.SetSubjects(subjectInfo)
 	}
 
+	store.SetAPICalls(nil)
+	store.SetResources(nil)
+	if values.Context != nil {
+		contextFullPath := getFullPath(values.Context.Resources, policyResourcePath, isGit)
+		contextResources, err := common.GetResourcesWithTest(fs, nil, contextFullPath, isGit, policyResourcePath)
+		if err != nil {
+			fmt.Fprintf(out, "Error: failed to load context resources\nCause: %s\n", err)
+			return loadError{sanitizederror.NewWithError("failed to load context resources", err)}
+		}
+		store.SetResources(contextResources)
+		store.SetAPICalls(values.Context.APICalls)
+	}
+
+	if values.Registry != nil {
+		if isGit {
+			return sanitizederror.NewWithError("failed to load registry layout", fmt.Errorf("registry layouts are not supported in git repositories"))
+		}
+		registryClient, cleanup, err := registryclient.InitLayoutClient(filepath.Join(policyResourcePath, values.Registry.Layout))
+		if err != nil {
+			return sanitizederror.NewWithError("failed to load registry layout", err)
+		}
+		defer cleanup()
+		defaultClient, registryAccess := registryclient.DefaultClient, store.GetRegistryAccess()
+		registryclient.DefaultClient = registryClient
+		store.RegistryAccess = true
+		defer func() {
+			registryclient.DefaultClient = defaultClient
+			store.RegistryAccess = registryAccess
+		}()
+	}
+
 	policyFullPath := getFullPath(values.Policies, policyResourcePath, isGit)
 	resourceFullPath := getFullPath(values.Resources, policyResourcePath, isGit)
 
@@ -882,17 +1083,25 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		generatedResourceFullPath := getFullPath(arrGeneratedResource, policyResourcePath, isGit)
 		CloneSourceResourceFullPath := getFullPath(arrCloneSourceResource, policyResourcePath, isGit)
 
-		values.Results[i].PatchedResource = patchedResourceFullPath[0]
-		values.Results[i].GeneratedResource = generatedResourceFullPath[0]
+		// resources are optional when the test asserts the patches or the generated resources
+		if result.PatchedResource != "" {
+			values.Results[i].PatchedResource = patchedResourceFullPath[0]
+		}
+		if result.GeneratedResource != "" {
+			values.Results[i].GeneratedResource = generatedResourceFullPath[0]
+		}
 		values.Results[i].CloneSourceResource = CloneSourceResourceFullPath[0]
 	}
 
 	policies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
-		os.Exit(1)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
+		return loadError{sanitizederror.NewWithError("failed to load policies", err)}
 	}
 
+	// the rules are filtered by the tests below, the coverage is computed on all rules
+	policyCoverage.addPolicies(policies)
+
 	filteredPolicies := []kyvernov1.PolicyInterface{}
 	for _, p := range policies {
 		for _, res := range values.Results {
@@ -914,13 +1123,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -950,8 +1159,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, mutatedPolicies, dClient, "", false, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		os.Exit(1)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
+		return loadError{sanitizederror.NewWithError("failed to load resources", err)}
 	}
 
 	filteredResources := []*unstructured.Unstructured{}
@@ -981,7 +1190,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	}
 
 	if len(mutatedPolicies) > 0 && len(resources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range mutatedPolicies {
@@ -998,7 +1207,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -1011,7 +1220,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				return sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
 
-			ers, info, err := common.ApplyPolicyOnResource(policy, resource, "", false, thisPolicyResourceValues, userInfo, true, namespaceSelectorMap, false, &resultCounts, false, ruleToCloneSourceResource)
+			ers, info, err := common.ApplyPolicyOnResource(policy, resource, "", false, thisPolicyResourceValues, userInfo, true, namespaceSelectorMap, false, &resultCounts, false, ruleToCloneSourceResource, out, policyCoverage.addBranches)
 			if err != nil {
 				return sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
 			}
@@ -1019,8 +1228,9 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
-	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
-	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
+	policyCoverage.addResponses(engineResponses)
+	resultsMap, testResults := buildPolicyResults(out, engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
+	resultErr := printTestResult(out, values.Name, resultsMap, testResults, engineResponses, rc, testCases, failOnly, removeColor, outputFormat)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
@@ -1028,8 +1238,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	return
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []TestResults, rc *resultCounts, failOnly, removeColor bool) error {
-	printer := tableprinter.New(os.Stdout)
+func printTestResult(out io.Writer, testName string, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []TestResults, engineResponses []*response.EngineResponse, rc *resultCounts, testCases *[]TestCase, failOnly, removeColor bool, outputFormat string) error {
+	printer := tableprinter.New(out)
 	table := []Table{}
 	boldGreen := color.New(color.FgGreen).Add(color.Bold)
 	boldRed := color.New(color.FgRed).Add(color.Bold)
@@ -1040,6 +1250,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 	for i, v := range testResults {
 		res := new(Table)
 		res.ID = i + 1
+		policyName := v.Policy
 		if !removeColor {
 			res.Policy = boldFgCyan.Sprintf(v.Policy)
 			res.Rule = boldFgCyan.Sprintf(v.Rule)
@@ -1099,6 +1310,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					rc.Fail++
 					table = append(table, *res)
 					ftable = append(ftable, *res)
+					*testCases = append(*testCases, newTestCase(testName, v, policyName, v.Namespace+"/"+v.Kind+"/"+resource, expectedResult(v), nil))
 					continue
 				}
 
@@ -1106,7 +1318,12 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					v.Result = v.Status
 				}
 
+				var assertionErr error
 				if testRes.Result == v.Result {
+					assertionErr = checkAssertions(v, ns, v.Policy, ruleNameInResultKey, resource, engineResponses)
+				}
+
+				if testRes.Result == v.Result && assertionErr == nil {
 					if !removeColor {
 						res.Result = boldGreen.Sprintf("Pass")
 					} else {
@@ -1118,7 +1335,11 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 						rc.Pass++
 					}
 				} else {
-					log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
+					if assertionErr != nil {
+						res.Reason = assertionErr.Error()
+					} else {
+						log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
+					}
 					if !removeColor {
 						res.Result = boldGreen.Sprintf("Fail")
 					} else {
@@ -1128,6 +1349,8 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					ftable = append(ftable, *res)
 				}
 
+				*testCases = append(*testCases, newTestCase(testName, v, policyName, v.Namespace+"/"+v.Kind+"/"+resource, v.Result, &testRes).withAssertionError(assertionErr))
+
 				if failOnly {
 					if res.Result == boldRed.Sprintf("Fail") || res.Result == "Fail" {
 						table = append(table, *res)
@@ -1187,6 +1410,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				rc.Fail++
 				table = append(table, *res)
 				ftable = append(ftable, *res)
+				*testCases = append(*testCases, newTestCase(testName, v, policyName, v.Namespace+"/"+v.Kind+"/"+v.Resource, expectedResult(v), nil))
 				continue
 			}
 
@@ -1194,7 +1418,12 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				v.Result = v.Status
 			}
 
+			var assertionErr error
 			if testRes.Result == v.Result {
+				assertionErr = checkAssertions(v, ns, v.Policy, ruleNameInResultKey, v.Resource, engineResponses)
+			}
+
+			if testRes.Result == v.Result && assertionErr == nil {
 				if !removeColor {
 					res.Result = boldGreen.Sprintf("Pass")
 				} else {
@@ -1206,7 +1435,11 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 					rc.Pass++
 				}
 			} else {
-				log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
+				if assertionErr != nil {
+					res.Reason = assertionErr.Error()
+				} else {
+					log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
+				}
 				if !removeColor {
 					res.Result = boldRed.Sprintf("Fail")
 				} else {
@@ -1216,6 +1449,8 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				ftable = append(ftable, *res)
 			}
 
+			*testCases = append(*testCases, newTestCase(testName, v, policyName, v.Namespace+"/"+v.Kind+"/"+v.Resource, v.Result, &testRes).withAssertionError(assertionErr))
+
 			if failOnly {
 				if res.Result == boldRed.Sprintf("Fail") || res.Result == "Fail" {
 					table = append(table, *res)
@@ -1227,7 +1462,10 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 	}
 
 	if countDeprecatedResource > 0 {
-		fmt.Printf("\n Note : The resource field is being deprecated in 1.8.0 release. Please provide the resources under the resources parameter as an array in the results field \n")
+		fmt.Fprintf(out, "\n Note : The resource field is being deprecated in 1.8.0 release. Please provide the resources under the resources parameter as an array in the results field \n")
+	}
+	if outputFormat != tableOutput {
+		return nil
 	}
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
@@ -1241,17 +1479,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 		printer.HeaderBgColor = tablewriter.BgBlackColor
 		printer.HeaderFgColor = tablewriter.FgGreenColor
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(table)
 	return nil
 }
 
-func printFailedTestResult() {
-	printer := tableprinter.New(os.Stdout)
+func printFailedTestResult(out io.Writer) {
+	printer := tableprinter.New(out)
 	for i, v := range ftable {
 		v.ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
@@ -1263,6 +1501,6 @@ func printFailedTestResult() {
 
 	printer.HeaderBgColor = tablewriter.BgBlackColor
 	printer.HeaderFgColor = tablewriter.FgGreenColor
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(ftable)
 }
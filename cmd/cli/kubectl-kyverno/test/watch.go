@@ -0,0 +1,275 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/watch"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// testSuite is a test file run by the watch mode
+type testSuite struct {
+	// file is the path of the test file.
+	file string
+	// paths are the test file and the files it references.
+	paths []string
+	// results are the test cases of the last run, by name.
+	results map[string]TestCase
+}
+
+// watchTests runs the test files of a local directory, then runs them again when the test files or the files they reference change
+func watchTests(out io.Writer, dirPath []string, fileName, testCase string, failOnly, removeColor bool) error {
+	if len(dirPath) == 0 {
+		return sanitizederror.New("a directory is required")
+	}
+
+	if strings.Contains(dirPath[0], "https://") {
+		return sanitizederror.New("watch is only supported for local directories")
+	}
+
+	root := filepath.Clean(dirPath[0])
+	tf := newTestFilter(out, testCase)
+	openAPIController, err := openapi.NewOpenAPIController()
+	if err != nil {
+		return sanitizederror.NewWithError("unable to create open api controller", err)
+	}
+
+	// the root directory is watched to find the new test files
+	watcher, err := watch.New(root)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to watch the test files", err)
+	}
+	defer watcher.Close()
+
+	files, err := findTestFiles(root, fileName)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to find the test files", err)
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named %s \n", fileName)
+	}
+
+	suites := map[string]*testSuite{}
+	for _, file := range files {
+		suite := &testSuite{file: file}
+		suite.run(out, openAPIController, tf, failOnly, removeColor)
+		if err := watcher.Add(suite.paths...); err != nil {
+			return sanitizederror.NewWithError("failed to watch the test files", err)
+		}
+		suites[file] = suite
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	fmt.Fprintf(out, "\nWatching for changes in %s, press Ctrl+C to stop...\n", root)
+	for {
+		changed, err := watcher.Wait(stop)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to watch the test files", err)
+		}
+
+		if changed == nil {
+			return nil
+		}
+
+		for _, file := range changed {
+			if _, ok := suites[file]; !ok && filepath.Base(file) == fileName {
+				suites[file] = &testSuite{file: file}
+			}
+		}
+
+		affected := make([]string, 0, len(suites))
+		for file, suite := range suites {
+			if watch.ContainsAny(append([]string{suite.file}, suite.paths...), changed) {
+				affected = append(affected, file)
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+		sort.Strings(affected)
+
+		for _, file := range affected {
+			suite := suites[file]
+			if _, err := os.Stat(file); err != nil {
+				fmt.Fprintf(out, "\nTest file %s was removed\n", file)
+				delete(suites, file)
+				continue
+			}
+
+			previous := suite.results
+			suite.run(out, openAPIController, tf, failOnly, removeColor)
+			if err := watcher.Add(suite.paths...); err != nil {
+				return sanitizederror.NewWithError("failed to watch the test files", err)
+			}
+
+			if previous != nil {
+				printResultDiff(out, previous, suite.results)
+			}
+		}
+
+		fmt.Fprintf(out, "\nWatching for changes in %s, press Ctrl+C to stop...\n", root)
+	}
+}
+
+func findTestFiles(root, fileName string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && info.Name() == fileName {
+			file, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+
+		return nil
+	})
+	return files, err
+}
+
+// run runs the test file and updates the results and the referenced files of the suite, failures are printed as the test command does
+func (s *testSuite) run(out io.Writer, openAPIController *openapi.Controller, tf *testFilter, failOnly, removeColor bool) {
+	ftable = []Table{}
+	rc := &resultCounts{}
+	testCases := []TestCase{}
+
+	if s.paths == nil {
+		s.paths = []string{s.file}
+	}
+
+	// We accept the risk of including files here as we read the watched dir only.
+	yamlFile, err := ioutil.ReadFile(s.file) // #nosec G304
+	if err != nil {
+		fmt.Fprintf(out, "Error: unable to read %s\nCause: %s\n", s.file, err)
+		return
+	}
+
+	valuesBytes, err := yaml.ToJSON(yamlFile)
+	if err != nil {
+		fmt.Fprintf(out, "Error: failed to convert %s to json\nCause: %s\n", s.file, err)
+		return
+	}
+
+	var test Test
+	if err := json.Unmarshal(valuesBytes, &test); err == nil {
+		s.paths = append([]string{s.file}, test.references(filepath.Dir(s.file))...)
+	}
+
+	if err := applyPoliciesFromPath(out, memfs.New(), valuesBytes, false, filepath.Dir(s.file), rc, openAPIController, tf, newCoverage(), &testCases, failOnly, removeColor, tableOutput); err != nil {
+		fmt.Fprintf(out, "\nError: failed to apply test command from file %s\nCause: %s\n", s.file, err)
+		return
+	}
+
+	s.results = map[string]TestCase{}
+	for _, tc := range testCases {
+		s.results[tc.name()] = tc
+	}
+
+	if !failOnly {
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+	} else {
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+}
+
+// references returns the files referenced by the test, relative to the directory of the test file
+func (t Test) references(dir string) []string {
+	paths := append([]string{}, t.Policies...)
+	paths = append(paths, t.Resources...)
+	if t.Variables != "" {
+		paths = append(paths, t.Variables)
+	}
+
+	if t.UserInfo != "" {
+		paths = append(paths, t.UserInfo)
+	}
+
+	if t.Context != nil {
+		paths = append(paths, t.Context.Resources...)
+	}
+
+	if t.Registry != nil && t.Registry.Layout != "" {
+		paths = append(paths, t.Registry.Layout)
+	}
+
+	for _, result := range t.Results {
+		for _, path := range []string{result.PatchedResource, result.GeneratedResource, result.CloneSourceResource} {
+			if path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return getFullPath(paths, dir, false)
+}
+
+func testResultStatus(tc TestCase) string {
+	if tc.Passed {
+		return "Pass"
+	}
+	return "Fail"
+}
+
+// printResultDiff prints the test results added, removed or changed since the previous run
+func printResultDiff(out io.Writer, previous, current map[string]TestCase) {
+	names := []string{}
+	for name := range previous {
+		names = append(names, name)
+	}
+
+	for name := range current {
+		if _, ok := previous[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, name := range names {
+		before, hadBefore := previous[name]
+		after, hasAfter := current[name]
+		switch {
+		case !hadBefore:
+			changes = append(changes, fmt.Sprintf("  + %s: %s", name, testResultStatus(after)))
+		case !hasAfter:
+			changes = append(changes, fmt.Sprintf("  - %s: %s", name, testResultStatus(before)))
+		case before.Passed != after.Passed || before.Actual != after.Actual:
+			changes = append(changes, fmt.Sprintf("  ~ %s: %s (%s) -> %s (%s)", name, testResultStatus(before), before.Actual, testResultStatus(after), after.Actual))
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "\nNo changes in the test results since the previous run\n")
+		return
+	}
+
+	fmt.Fprintf(out, "\nChanges in the test results since the previous run:\n")
+	for _, change := range changes {
+		fmt.Fprintln(out, change)
+	}
+}
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_references(t *testing.T) {
+	test := Test{
+		Policies:  []string{"policy.yaml"},
+		Resources: []string{"resources/"},
+		Variables: "values.yaml",
+		Context:   &TestContext{Resources: []string{"configmaps.yaml"}},
+		Registry:  &TestRegistry{Layout: "registry"},
+		Results: []TestResults{
+			{PatchedResource: "patched.yaml"},
+			{GeneratedResource: "generated.yaml"},
+		},
+	}
+
+	dir := filepath.Join("tests", "simple")
+	assert.DeepEqual(t, test.references(dir), []string{
+		filepath.Join(dir, "policy.yaml"),
+		filepath.Join(dir, "resources"),
+		filepath.Join(dir, "values.yaml"),
+		filepath.Join(dir, "configmaps.yaml"),
+		filepath.Join(dir, "registry"),
+		filepath.Join(dir, "patched.yaml"),
+		filepath.Join(dir, "generated.yaml"),
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -398,7 +399,7 @@ func MutatePolicies(policies []kyvernov1.PolicyInterface) ([]kyvernov1.PolicyInt
 func ApplyPolicyOnResource(policy kyvernov1.PolicyInterface, resource *unstructured.Unstructured,
 	mutateLogPath string, mutateLogPathIsDir bool, variables map[string]interface{}, userInfo kyvernov1beta1.RequestInfo, policyReport bool,
 	namespaceSelectorMap map[string]map[string]string, stdin bool, rc *ResultCounts,
-	printPatchResource bool, ruleToCloneSourceResource map[string]string,
+	printPatchResource bool, ruleToCloneSourceResource map[string]string, out io.Writer, branchRecorder engine.BranchRecorder,
 ) ([]*response.EngineResponse, policyreport.Info, error) {
 	var engineResponses []*response.EngineResponse
 	namespaceLabels := make(map[string]string)
@@ -497,6 +498,7 @@ OuterLoop:
 		JSONContext:     ctx,
 		NamespaceLabels: namespaceLabels,
 		AdmissionInfo:   userInfo,
+		BranchRecorder:  branchRecorder,
 	}
 
 	mutateResponse := engine.Mutate(policyContext)
@@ -504,7 +506,7 @@ OuterLoop:
 		engineResponses = append(engineResponses, mutateResponse)
 	}
 
-	err = processMutateEngineResponse(policy, mutateResponse, resPath, rc, mutateLogPath, stdin, mutateLogPathIsDir, resource.GetName(), printPatchResource)
+	err = processMutateEngineResponse(out, policy, mutateResponse, resPath, rc, mutateLogPath, stdin, mutateLogPathIsDir, resource.GetName(), printPatchResource)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
 			return engineResponses, policyreport.Info{}, sanitizederror.NewWithError("failed to print mutated result", err)
@@ -534,7 +536,7 @@ OuterLoop:
 	var validateResponse *response.EngineResponse
 	if policyHasValidate {
 		validateResponse = engine.Validate(policyContext)
-		info = ProcessValidateEngineResponse(policy, validateResponse, resPath, rc, policyReport)
+		info = ProcessValidateEngineResponse(out, policy, validateResponse, resPath, rc, policyReport)
 	}
 
 	if validateResponse != nil && !validateResponse.IsEmpty() {
@@ -544,7 +546,7 @@ OuterLoop:
 	verifyImageResponse, _ := engine.VerifyAndPatchImages(policyContext)
 	if verifyImageResponse != nil && !verifyImageResponse.IsEmpty() {
 		engineResponses = append(engineResponses, verifyImageResponse)
-		info = ProcessValidateEngineResponse(policy, verifyImageResponse, resPath, rc, policyReport)
+		info = ProcessValidateEngineResponse(out, policy, verifyImageResponse, resPath, rc, policyReport)
 	}
 
 	var policyHasGenerate bool
@@ -575,7 +577,7 @@ OuterLoop:
 			}
 			engineResponses = append(engineResponses, generateResponse)
 		}
-		updateResultCounts(policy, generateResponse, resPath, rc)
+		updateResultCounts(out, policy, generateResponse, resPath, rc)
 	}
 
 	return engineResponses, info, nil
@@ -727,8 +729,35 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 	return resources, err
 }
 
-func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool) policyreport.Info {
+// IsScored returns false for the policies annotated with policies.kyverno.io/scored: "false"
+func IsScored(policy kyvernov1.PolicyInterface) bool {
+	scored, ok := policy.GetAnnotations()[policyreport.ScoredLabel]
+	return !ok || scored != "false"
+}
+
+// ToPolicyResult maps the status of a rule to a policy report result,
+// the failed rules of the policies that are not scored are reported as warnings
+func ToPolicyResult(status response.RuleStatus, scored bool) policyreportv1alpha2.PolicyResult {
+	switch status {
+	case response.RuleStatusPass:
+		return policyreportv1alpha2.StatusPass
+	case response.RuleStatusFail:
+		if !scored {
+			return policyreportv1alpha2.StatusWarn
+		}
+		return policyreportv1alpha2.StatusFail
+	case response.RuleStatusWarn:
+		return policyreportv1alpha2.StatusWarn
+	case response.RuleStatusError:
+		return policyreportv1alpha2.StatusError
+	default:
+		return policyreportv1alpha2.StatusSkip
+	}
+}
+
+func ProcessValidateEngineResponse(out io.Writer, policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool) policyreport.Info {
 	var violatedRules []kyvernov1.ViolatedRule
+	scored := IsScored(policy)
 
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
@@ -744,44 +773,32 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 					Name:    valResponseRule.Name,
 					Type:    string(valResponseRule.Type),
 					Message: valResponseRule.Message,
+					Status:  string(ToPolicyResult(valResponseRule.Status, scored)),
 				}
 
-				switch valResponseRule.Status {
-				case response.RuleStatusPass:
+				switch vrule.Status {
+				case policyreportv1alpha2.StatusPass:
 					rc.Pass++
-					vrule.Status = policyreportv1alpha2.StatusPass
-
-				case response.RuleStatusFail:
-					ann := policy.GetAnnotations()
-					if scored, ok := ann[policyreport.ScoredLabel]; ok && scored == "false" {
-						rc.Warn++
-						vrule.Status = policyreportv1alpha2.StatusWarn
-						break
-					} else {
-						rc.Fail++
-						vrule.Status = policyreportv1alpha2.StatusFail
-					}
 
+				case policyreportv1alpha2.StatusFail:
+					rc.Fail++
 					if !policyReport {
 						if printCount < 1 {
-							fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
+							fmt.Fprintf(out, "\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
 							printCount++
 						}
 
-						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
+						fmt.Fprintf(out, "%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
-				case response.RuleStatusError:
+				case policyreportv1alpha2.StatusError:
 					rc.Error++
-					vrule.Status = policyreportv1alpha2.StatusError
 
-				case response.RuleStatusWarn:
+				case policyreportv1alpha2.StatusWarn:
 					rc.Warn++
-					vrule.Status = policyreportv1alpha2.StatusWarn
 
-				case response.RuleStatusSkip:
+				case policyreportv1alpha2.StatusSkip:
 					rc.Skip++
-					vrule.Status = policyreportv1alpha2.StatusSkip
 				}
 
 				violatedRules = append(violatedRules, vrule)
@@ -817,7 +834,7 @@ func buildPVInfo(er *response.EngineResponse, violatedRules []kyvernov1.Violated
 	return info
 }
 
-func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts) {
+func updateResultCounts(out io.Writer, policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts) {
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
@@ -829,10 +846,10 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *respon
 					rc.Pass++
 				} else {
 					if printCount < 1 {
-						fmt.Println("\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
+						fmt.Fprintln(out, "\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
+					fmt.Fprintf(out, "%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
 					rc.Fail++
 				}
 				continue
@@ -879,7 +896,7 @@ func SetInStoreContext(mutatedPolicies []kyvernov1.PolicyInterface, variables ma
 	return variables
 }
 
-func processMutateEngineResponse(policy kyvernov1.PolicyInterface, mutateResponse *response.EngineResponse, resPath string, rc *ResultCounts, mutateLogPath string, stdin bool, mutateLogPathIsDir bool, resourceName string, printPatchResource bool) error {
+func processMutateEngineResponse(out io.Writer, policy kyvernov1.PolicyInterface, mutateResponse *response.EngineResponse, resPath string, rc *ResultCounts, mutateLogPath string, stdin bool, mutateLogPathIsDir bool, resourceName string, printPatchResource bool) error {
 	var policyHasMutate bool
 	for _, rule := range autogen.ComputeRules(policy) {
 		if rule.HasMutate() {
@@ -901,17 +918,17 @@ func processMutateEngineResponse(policy kyvernov1.PolicyInterface, mutateRespons
 					rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status == response.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", policy.GetName(), resPath)
+					fmt.Fprintf(out, "\nskipped mutate policy %s -> resource %s", policy.GetName(), resPath)
 					rc.Skip++
 				} else if mutateResponseRule.Status == response.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", policy.GetName(), resPath, mutateResponseRule.Message)
+					fmt.Fprintf(out, "\nerror while applying mutate policy %s -> resource %s\nerror: %s", policy.GetName(), resPath, mutateResponseRule.Message)
 					rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", policy.GetName(), resPath)
+						fmt.Fprintf(out, "\nfailed to apply mutate policy %s -> resource %s", policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
+					fmt.Fprintf(out, "%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
 					rc.Fail++
 				}
 				continue
@@ -932,16 +949,16 @@ func processMutateEngineResponse(policy kyvernov1.PolicyInterface, mutateRespons
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", policy.GetName(), resPath)
+					fmt.Fprintf(out, "\nmutate policy %s applied to %s:", policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprintf(out, "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(mutateLogPath, mutateLogPathIsDir, string(yamlEncodedResource), resourceName+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(out, "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -17,9 +17,13 @@ import (
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/pager"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/yaml"
 )
@@ -31,21 +35,7 @@ import (
 func GetResources(policies []kyvernov1.PolicyInterface, resourcePaths []string, dClient dclient.Interface, cluster bool, namespace string, policyReport bool) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	var err error
-	resourceTypesMap := make(map[string]bool)
-	var resourceTypes []string
-
-	for _, policy := range policies {
-		for _, rule := range autogen.ComputeRules(policy) {
-			resourceTypesInRule := GetKindsFromRule(rule)
-			for resourceKind := range resourceTypesInRule {
-				resourceTypesMap[resourceKind] = true
-			}
-		}
-	}
-
-	for kind := range resourceTypesMap {
-		resourceTypes = append(resourceTypes, kind)
-	}
+	resourceTypes := GetKindsFromPolicies(policies)
 
 	if cluster && dClient != nil {
 		resources, err = whenClusterIsTrue(resourceTypes, dClient, namespace, resourcePaths, policyReport)
@@ -211,6 +201,57 @@ func getResourcesOfTypeFromCluster(resourceTypes []string, dClient dclient.Inter
 	return r, nil
 }
 
+// GetKindsFromPolicies returns the kinds matched by the rules of the policies, including the autogen rules
+func GetKindsFromPolicies(policies []kyvernov1.PolicyInterface) []string {
+	resourceTypesMap := make(map[string]bool)
+	var resourceTypes []string
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			for resourceKind := range GetKindsFromRule(rule) {
+				if !resourceTypesMap[resourceKind] {
+					resourceTypesMap[resourceKind] = true
+					resourceTypes = append(resourceTypes, resourceKind)
+				}
+			}
+		}
+	}
+	return resourceTypes
+}
+
+// ListResourcesOfType lists the resources of a kind from the cluster page by page and calls fn for each resource,
+// pageSize is the maximum number of resources returned by each request, 0 lists all resources at once.
+// The namespace is ignored for cluster scoped kinds.
+func ListResourcesOfType(ctx context.Context, dClient dclient.Interface, kind string, namespace string, pageSize int64, fn func(*unstructured.Unstructured) error) error {
+	gvr, err := dClient.Discovery().GetGVRFromKind(kind)
+	if err != nil {
+		return err
+	}
+
+	if gvr.Empty() {
+		return fmt.Errorf("kind %s not found in cluster", kind)
+	}
+
+	var resourceInterface dynamic.ResourceInterface = dClient.GetDynamicInterface().Resource(gvr)
+	if namespace != "" {
+		if apiResource, _, err := dClient.Discovery().FindResource("", kind); err != nil || apiResource.Namespaced {
+			resourceInterface = dClient.GetDynamicInterface().Resource(gvr).Namespace(namespace)
+		}
+	}
+
+	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return resourceInterface.List(ctx, opts)
+	})
+	listPager.PageSize = pageSize
+
+	return listPager.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		resource, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected object type %T", obj)
+		}
+		return fn(resource)
+	})
+}
+
 func getFileBytes(path string) ([]byte, error) {
 	var (
 		file []byte
@@ -0,0 +1,226 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/getter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// RenderOptions are the kustomizations and Helm charts rendered locally as resources
+type RenderOptions struct {
+	// KustomizeDirs are the directories containing a kustomization file.
+	KustomizeDirs []string
+	// HelmCharts are the paths to the Helm chart directories or archives.
+	HelmCharts []string
+	// HelmValues are the values files of the Helm charts, later files take precedence.
+	HelmValues []string
+	// HelmNamespace is the namespace of the Helm releases, default if empty.
+	HelmNamespace string
+	// IncludeCRDs renders the CRDs of the Helm charts too, like `helm template --include-crds`.
+	IncludeCRDs bool
+}
+
+// IsEmpty returns true when there is nothing to render
+func (o RenderOptions) IsEmpty() bool {
+	return len(o.KustomizeDirs) == 0 && len(o.HelmCharts) == 0
+}
+
+// Paths returns the paths read to render the resources
+func (o RenderOptions) Paths() []string {
+	var paths []string
+	paths = append(paths, o.KustomizeDirs...)
+	paths = append(paths, o.HelmCharts...)
+	paths = append(paths, o.HelmValues...)
+	return paths
+}
+
+// RenderedResource is a resource rendered from a kustomization or Helm chart
+type RenderedResource struct {
+	Resource *unstructured.Unstructured
+	// Source is the kustomization directory or the template file of the chart the resource was rendered from.
+	Source string
+}
+
+// GetRenderedResources renders the kustomizations and Helm charts, without external binaries
+func GetRenderedResources(opts RenderOptions) ([]RenderedResource, error) {
+	var resources []RenderedResource
+	for _, dir := range opts.KustomizeDirs {
+		rendered, err := RenderKustomization(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render kustomization %s: %w", dir, err)
+		}
+		resources = append(resources, rendered...)
+	}
+
+	if len(opts.HelmValues) > 0 && len(opts.HelmCharts) == 0 {
+		return nil, fmt.Errorf("values files require a Helm chart")
+	}
+
+	for _, chartPath := range opts.HelmCharts {
+		rendered, err := RenderHelmChart(chartPath, opts.HelmValues, opts.HelmNamespace, opts.IncludeCRDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render Helm chart %s: %w", chartPath, err)
+		}
+		resources = append(resources, rendered...)
+	}
+
+	return resources, nil
+}
+
+// RenderKustomization builds a kustomization like `kustomize build` does
+func RenderKustomization(dir string) ([]RenderedResource, error) {
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlBytes, err := resMap.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := GetResource(yamlBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]RenderedResource, 0, len(resources))
+	for _, resource := range resources {
+		rendered = append(rendered, RenderedResource{Resource: resource, Source: dir})
+	}
+
+	return rendered, nil
+}
+
+// RenderHelmChart renders the templates of a chart like `helm template` does, the release is named after the chart.
+// The CRDs of the chart are only rendered with includeCRDs, the resources without a namespace are placed in the release namespace.
+func RenderHelmChart(chartPath string, valuesFiles []string, namespace string, includeCRDs bool) ([]RenderedResource, error) {
+	chart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	valueOpts := &values.Options{ValueFiles: valuesFiles}
+	vals, err := valueOpts.MergeValues(getter.Providers{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chartutil.ProcessDependencies(chart, vals); err != nil {
+		return nil, err
+	}
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      chart.Name(),
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+
+	renderValues, err := chartutil.ToRenderValues(chart, vals, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := engine.Render(chart, renderValues)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeCRDs {
+		for _, crd := range chart.CRDObjects() {
+			templates[crd.Filename] = string(crd.File.Data)
+		}
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// the resources of an archived chart are located in the archive
+	sourceDir := ""
+	if info, err := os.Stat(chartPath); err == nil && info.IsDir() {
+		sourceDir = chartPath
+	}
+
+	var rendered []RenderedResource
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || strings.EqualFold(base, "NOTES.txt") || strings.TrimSpace(templates[name]) == "" {
+			continue
+		}
+
+		documents, err := yamlutils.SplitDocuments([]byte(templates[name]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to split %s: %w", name, err)
+		}
+
+		for _, document := range documents {
+			resource, err := renderedHelmResource(document, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+
+			if resource == nil {
+				continue
+			}
+
+			source := chartPath
+			if sourceDir != "" {
+				source = filepath.Join(sourceDir, filepath.FromSlash(strings.TrimPrefix(name, chart.Name()+"/")))
+			}
+			rendered = append(rendered, RenderedResource{Resource: resource, Source: source})
+		}
+	}
+
+	return rendered, nil
+}
+
+// renderedHelmResource converts a rendered document to a resource in the release namespace, nil if the document is empty
+func renderedHelmResource(document []byte, namespace string) (*unstructured.Unstructured, error) {
+	var object map[string]interface{}
+	if err := yaml.Unmarshal(document, &object); err != nil {
+		return nil, err
+	}
+
+	if len(object) == 0 {
+		return nil, nil
+	}
+
+	resources, err := GetResource(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	resource := resources[0]
+	if ns, _, _ := unstructured.NestedString(object, "metadata", "namespace"); ns == "" {
+		resource.SetNamespace(namespace)
+	}
+
+	return resource, nil
+}
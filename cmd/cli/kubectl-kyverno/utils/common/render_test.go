@@ -0,0 +1,134 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		assert.NilError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func Test_RenderKustomization(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kustomize")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"base/kustomization.yaml": `resources:
+- pod.yaml
+`,
+		"base/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.21
+`,
+		"overlay/kustomization.yaml": `namespace: prod
+namePrefix: prod-
+commonLabels:
+  env: prod
+resources:
+- ../base
+`,
+	})
+
+	overlay := filepath.Join(dir, "overlay")
+	rendered, err := RenderKustomization(overlay)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rendered), 1)
+	assert.Equal(t, rendered[0].Source, overlay)
+	assert.Equal(t, rendered[0].Resource.GetName(), "prod-nginx")
+	assert.Equal(t, rendered[0].Resource.GetNamespace(), "prod")
+	assert.Equal(t, rendered[0].Resource.GetLabels()["env"], "prod")
+}
+
+func Test_RenderHelmChart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"chart/Chart.yaml": `apiVersion: v2
+name: web
+version: 0.1.0
+`,
+		"chart/values.yaml": `image: nginx:latest
+replicas: 1
+`,
+		"chart/templates/_helpers.tpl": `{{- define "web.name" -}}{{ .Release.Name }}-app{{- end -}}`,
+		"chart/templates/NOTES.txt":    `Installed {{ .Release.Name }}`,
+		"chart/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "web.name" . }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: web
+        image: {{ .Values.image }}
+`,
+		"chart/templates/service.yaml": `{{- if .Values.service }}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "web.name" . }}
+  namespace: ingress
+{{- end }}
+`,
+		"chart/crds/widgets.yaml": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+`,
+		"values.yaml": `image: nginx:1.21
+service: true
+`,
+	})
+
+	chart := filepath.Join(dir, "chart")
+	rendered, err := RenderHelmChart(chart, nil, "", false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rendered), 1)
+	assert.Equal(t, rendered[0].Source, filepath.Join(chart, "templates", "deployment.yaml"))
+	assert.Equal(t, rendered[0].Resource.GetName(), "web-app")
+	assert.Equal(t, rendered[0].Resource.GetNamespace(), "default")
+
+	// the CRDs of the chart are only rendered when asked
+	rendered, err = RenderHelmChart(chart, nil, "", true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rendered), 2)
+	assert.Equal(t, rendered[0].Resource.GetKind(), "CustomResourceDefinition")
+	assert.Equal(t, rendered[0].Source, filepath.Join(chart, "crds", "widgets.yaml"))
+
+	rendered, err = GetRenderedResources(RenderOptions{
+		HelmCharts:    []string{chart},
+		HelmValues:    []string{filepath.Join(dir, "values.yaml")},
+		HelmNamespace: "prod",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(rendered), 2)
+
+	deployment := rendered[0].Resource
+	assert.Equal(t, deployment.GetNamespace(), "prod")
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, containers[0].(map[string]interface{})["image"], "nginx:1.21")
+
+	// the namespace of the templates is kept
+	assert.Equal(t, rendered[1].Resource.GetKind(), "Service")
+	assert.Equal(t, rendered[1].Resource.GetNamespace(), "ingress")
+}
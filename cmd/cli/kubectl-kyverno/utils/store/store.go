@@ -3,6 +3,7 @@ package store
 import (
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var (
@@ -10,6 +11,8 @@ var (
 	ContextVar           Context
 	ForeachElement       int
 	Subjects             Subject
+	APICalls             []APICall
+	Resources            []*unstructured.Unstructured
 )
 
 func SetMock(mock bool) {
@@ -92,6 +95,42 @@ func GetSubjects() Subject {
 	return Subjects
 }
 
+// APICall is a canned response for the apiCall context entries using the URL path
+type APICall struct {
+	// URLPath is the URL path of the API call, after variable substitution
+	URLPath string `json:"urlPath"`
+	// Response is the data returned by the API call
+	Response interface{} `json:"response"`
+}
+
+func SetAPICalls(apiCalls []APICall) {
+	APICalls = apiCalls
+}
+
+// GetAPICall returns the canned response for the URL path, or nil if there is none
+func GetAPICall(urlPath string) *APICall {
+	for i := range APICalls {
+		if APICalls[i].URLPath == urlPath {
+			return &APICalls[i]
+		}
+	}
+	return nil
+}
+
+func SetResources(resources []*unstructured.Unstructured) {
+	Resources = resources
+}
+
+// GetResource returns the mocked resource, or nil if there is none
+func GetResource(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	for _, resource := range Resources {
+		if resource.GetAPIVersion() == apiVersion && resource.GetKind() == kind && resource.GetNamespace() == namespace && resource.GetName() == name {
+			return resource
+		}
+	}
+	return nil
+}
+
 type Subject struct {
 	Subject rbacv1.Subject `json:"subject,omitempty" yaml:"subject,omitempty"`
 }
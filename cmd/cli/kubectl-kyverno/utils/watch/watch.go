@@ -0,0 +1,189 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultDebounce is the time to wait for more changes after a change, editors usually write a file in several operations
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watcher notifies the changes of local files and directories, directories are watched recursively.
+// The parent directories of the files are watched instead of the files, so that the files replaced by editors are still watched.
+type Watcher struct {
+	watcher  *fsnotify.Watcher
+	paths    map[string]bool
+	dirs     map[string]bool
+	debounce time.Duration
+}
+
+// New creates a watcher for the paths, remote (http and https) paths and stdin (-) are ignored
+func New(paths ...string) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		watcher:  watcher,
+		paths:    map[string]bool{},
+		dirs:     map[string]bool{},
+		debounce: DefaultDebounce,
+	}
+	if err := w.Add(paths...); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Add adds paths to the watcher
+func (w *Watcher) Add(paths ...string) error {
+	for _, path := range paths {
+		if path == "" || path == "-" || common.IsHTTPRegex.MatchString(path) {
+			continue
+		}
+
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		w.paths[path] = true
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			// watch the parent directory to be notified when the file is created or replaced
+			if err := w.addDir(filepath.Dir(path)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return nil
+			}
+			return w.addDir(p)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Watcher) addDir(dir string) error {
+	if w.dirs[dir] {
+		return nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		log.Log.V(3).Info("skipping missing directory", "path", dir)
+		return nil
+	}
+
+	if err := w.watcher.Add(dir); err != nil {
+		return err
+	}
+
+	w.dirs[dir] = true
+	return nil
+}
+
+// Wait blocks until watched paths changed and returns the changed files, it returns nil when stop is closed
+func (w *Watcher) Wait(stop <-chan struct{}) ([]string, error) {
+	changed := map[string]bool{}
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-stop:
+			return nil, nil
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil, nil
+			}
+			return nil, err
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil, nil
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			if !w.isWatched(event.Name) {
+				continue
+			}
+
+			// watch the directories created in watched directories
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				if err := w.Add(event.Name); err != nil {
+					return nil, err
+				}
+			}
+
+			changed[event.Name] = true
+			timer = time.After(w.debounce)
+
+		case <-timer:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			return files, nil
+		}
+	}
+}
+
+func (w *Watcher) isWatched(file string) bool {
+	for path := range w.paths {
+		if Contains(path, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close stops watching the paths
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
+
+// Contains checks if the file is the path or is in the path directory
+func Contains(path, file string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	file, err = filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	return file == path || strings.HasPrefix(file, path+string(filepath.Separator))
+}
+
+// ContainsAny checks if one of the files is one of the paths or is in one of the path directories
+func ContainsAny(paths []string, files []string) bool {
+	for _, path := range paths {
+		for _, file := range files {
+			if Contains(path, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package watch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_Contains(t *testing.T) {
+	assert.Assert(t, Contains("/policies", "/policies"))
+	assert.Assert(t, Contains("/policies", "/policies/pod/policy.yaml"))
+	assert.Assert(t, !Contains("/policies", "/policies-old/policy.yaml"))
+	assert.Assert(t, !Contains("/policies/policy.yaml", "/policies/resource.yaml"))
+	assert.Assert(t, ContainsAny([]string{"/resources", "/policies"}, []string{"/policies/policy.yaml"}))
+	assert.Assert(t, !ContainsAny([]string{"/resources"}, []string{"/policies/policy.yaml"}))
+}
+
+func Test_Wait(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	policy := filepath.Join(dir, "policy.yaml")
+	assert.NilError(t, ioutil.WriteFile(policy, []byte("kind: ClusterPolicy"), 0o600))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "other.yaml"), []byte("kind: Pod"), 0o600))
+
+	watcher, err := New(policy, "https://github.com/kyverno/policies", "-")
+	assert.NilError(t, err)
+	defer watcher.Close()
+	watcher.debounce = 10 * time.Millisecond
+
+	// the files of the watched directory that are not watched are ignored
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "other.yaml"), []byte("kind: Deployment"), 0o600))
+	assert.NilError(t, ioutil.WriteFile(policy, []byte("kind: Policy"), 0o600))
+
+	changed, err := watcher.Wait(nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed, []string{policy})
+
+	stop := make(chan struct{})
+	close(stop)
+	changed, err = watcher.Wait(stop)
+	assert.NilError(t, err)
+	assert.Assert(t, changed == nil)
+}
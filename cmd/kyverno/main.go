@@ -17,6 +17,7 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/wrappers"
 	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/contextcache"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
@@ -244,6 +245,13 @@ func main() {
 		cosign.ImageSignatureRepository = imageSignatureRepository
 	}
 
+	// initialize the cache used by apiCall and configMap context entries
+	var cacheMetricsConfig metrics.MetricsConfigManager
+	if metricsConfig != nil {
+		cacheMetricsConfig = metricsConfig
+	}
+	contextcache.DefaultCache = contextcache.NewCache(dynamicClient, cacheMetricsConfig, stopCh)
+
 	// EVENT GENERATOR
 	// - generate event with retry mechanism
 	eventGenerator := event.NewEventGenerator(dynamicClient, kyvernoV1.ClusterPolicies(), kyvernoV1.Policies(), maxQueuedEvents, log.Log.WithName("EventGenerator"))
@@ -463,6 +471,7 @@ func main() {
 		eventGenerator,
 		auditHandler,
 		openAPIController,
+		autoUpdateWebhooks,
 	)
 
 	server := webhooks.NewServer(
@@ -22,12 +22,14 @@ import (
 	pkgcommon "github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
+	enginecommon "github.com/kyverno/kyverno/pkg/engine/common"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/event"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -168,6 +170,12 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 
 const doesNotApply = "policy does not apply to resource"
 
+// foreachRuleLabel is set on the resources generated for the elements of a foreach to the name of the rule
+const foreachRuleLabel = "generate.kyverno.io/foreach-rule-name"
+
+// cloneListRuleLabel is set on the resources cloned from the sources of a clone list to the name of the rule
+const cloneListRuleLabel = "generate.kyverno.io/clone-list-rule-name"
+
 func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, bool, error) {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.Policy, "kind", ur.Spec.Resource.Kind, "apiVersion", ur.Spec.Resource.APIVersion, "namespace", ur.Spec.Resource.Namespace, "name", ur.Spec.Resource.Name)
 	logger.V(3).Info("applying generate policy rule")
@@ -346,20 +354,33 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			return nil, processExisting, err
 		}
 
+		// the foreach declarations are substituted for each element
+		forEach := rule.Generation.ForEachGeneration
+		rule.Generation.ForEachGeneration = nil
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext, rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
 			return nil, processExisting, err
 		}
 
 		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() || !processExisting {
-			genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+			if len(forEach) > 0 {
+				var forEachResources []kyvernov1.ResourceSpec
+				forEachResources, err = applyForEach(log, c.client, rule, forEach, policyContext, ur)
+				genResources = append(genResources, forEachResources...)
+			} else if !rule.Generation.CloneList.IsEmpty() {
+				var cloneResources []kyvernov1.ResourceSpec
+				cloneResources, err = applyCloneList(log, c.client, rule, resource, jsonContext, policy, ur)
+				genResources = append(genResources, cloneResources...)
+			} else {
+				genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur, nil)
+				genResources = append(genResources, genResource)
+			}
 			if err != nil {
 				log.Error(err, "failed to apply generate rule", "policy", policy.GetName(),
 					"rule", rule.Name, "resource", resource.GetName(), "suggestion", "users need to grant Kyverno's service account additional privileges")
 				return nil, processExisting, err
 			}
 			ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
-			genResources = append(genResources, genResource)
 		}
 
 		if policy.GetSpec().IsGenerateExistingOnPolicyUpdate() {
@@ -392,7 +413,7 @@ func getResourceInfo(object map[string]interface{}) (kind, name, namespace, apiv
 	return
 }
 
-func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx context.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) (kyvernov1.ResourceSpec, error) {
+func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx context.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest, ruleLabels map[string]string) (kyvernov1.ResourceSpec, error) {
 	var rdata map[string]interface{}
 	var err error
 	var mode ResourceMode
@@ -471,6 +492,9 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 
 	label["policy.kyverno.io/policy-name"] = policy.GetName()
 	label["policy.kyverno.io/gr-name"] = ur.Name
+	for k, v := range ruleLabels {
+		label[k] = v
+	}
 	if mode == Create {
 		if rule.Generation.Synchronize {
 			label["policy.kyverno.io/synchronize"] = "enable"
@@ -544,6 +568,242 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, r
 	return newGenResource, nil
 }
 
+// applyCloneList clones the source resources selected by the clone list of the rule in the namespace
+// of the generate rule, each source resource is generated with its own kind and name. When the rule is
+// synchronized, the clones of the sources that are deleted or not selected anymore are deleted.
+func applyCloneList(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, resource unstructured.Unstructured, ctx context.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+	var genResources []kyvernov1.ResourceSpec
+	ruleLabels := map[string]string{cloneListRuleLabel: rule.Name}
+	cloneList := rule.Generation.CloneList
+	for _, kind := range cloneList.Kinds {
+		apiVersion, k := kubeutils.GetKindFromGVK(kind)
+		sources, err := client.ListResource(apiVersion, k, cloneList.Namespace, cloneList.Selector)
+		if err != nil {
+			return genResources, fmt.Errorf("failed to list source resources %s in namespace %s: %v", kind, cloneList.Namespace, err)
+		}
+
+		for i := range sources.Items {
+			source := &sources.Items[i]
+			if source.GetNamespace() == rule.Generation.Namespace {
+				log.V(4).Info("skip resource self-clone", "kind", source.GetKind(), "name", source.GetName())
+				continue
+			}
+
+			if rule.Generation.Synchronize {
+				if err := addSourceLabel(client, source, policy.GetName()); err != nil {
+					log.Error(err, "failed to update source", "kind", source.GetKind(), "name", source.GetName(), "namespace", source.GetNamespace())
+				}
+			}
+
+			cloneRule := *rule.DeepCopy()
+			cloneRule.Generation.ResourceSpec = kyvernov1.ResourceSpec{
+				APIVersion: source.GetAPIVersion(),
+				Kind:       source.GetKind(),
+				Namespace:  rule.Generation.Namespace,
+				Name:       source.GetName(),
+			}
+			cloneRule.Generation.Clone = kyvernov1.CloneFrom{Namespace: source.GetNamespace(), Name: source.GetName()}
+			cloneRule.Generation.CloneList = kyvernov1.CloneList{}
+
+			genResource, err := applyRule(log, client, cloneRule, resource, ctx, policy, ur, ruleLabels)
+			if err != nil {
+				return genResources, err
+			}
+			genResources = append(genResources, genResource)
+		}
+	}
+
+	if rule.Generation.Synchronize {
+		if err := deleteRemovedResources(log, client, cloneListRuleLabel, rule.Name, ur, genResources); err != nil {
+			return genResources, err
+		}
+	}
+	return genResources, nil
+}
+
+// applyForEach generates a resource for each element of the foreach lists of the rule. When the rule is synchronized,
+// the resources previously generated for the elements that are removed from the lists are deleted.
+func applyForEach(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, forEach []kyvernov1.ForEachGeneration, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+	var genResources []kyvernov1.ResourceSpec
+	ruleLabels := map[string]string{foreachRuleLabel: rule.Name}
+	for i, fe := range forEach {
+		elements, err := evaluateList(fe.List, policyContext.JSONContext)
+		if err != nil {
+			return genResources, fmt.Errorf("failed to evaluate generate.foreach[%d].list %s: %v", i, fe.List, err)
+		}
+
+		resources, err := applyForEachElements(log, client, rule, fe, elements, policyContext, ur, ruleLabels)
+		genResources = append(genResources, resources...)
+		if err != nil {
+			return genResources, fmt.Errorf("failed to apply generate.foreach[%d]: %v", i, err)
+		}
+	}
+
+	if rule.Generation.Synchronize {
+		if err := deleteRemovedResources(log, client, foreachRuleLabel, rule.Name, ur, genResources); err != nil {
+			return genResources, err
+		}
+	}
+	return genResources, nil
+}
+
+func applyForEachElements(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, fe kyvernov1.ForEachGeneration, elements []interface{}, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, ruleLabels map[string]string) ([]kyvernov1.ResourceSpec, error) {
+	ctx := policyContext.JSONContext
+	ctx.Checkpoint()
+	defer ctx.Restore()
+
+	var genResources []kyvernov1.ResourceSpec
+	for index, element := range elements {
+		ctx.Reset()
+		data, err := variables.DocumentToUntyped(element)
+		if err != nil {
+			return genResources, err
+		}
+		if err := ctx.AddElement(data, index); err != nil {
+			return genResources, fmt.Errorf("failed to add element (%v) to JSON context: %v", element, err)
+		}
+
+		if err := engine.LoadContext(log, fe.Context, policyContext, rule.Name); err != nil {
+			return genResources, fmt.Errorf("failed to load context: %v", err)
+		}
+
+		preconditions, err := variables.SubstituteAllInPreconditions(log, ctx, fe.AnyAllConditions)
+		if err != nil {
+			return genResources, fmt.Errorf("failed to substitute variables in preconditions: %v", err)
+		}
+		conditions, err := enginecommon.TransformConditions(preconditions)
+		if err != nil {
+			return genResources, fmt.Errorf("failed to parse preconditions: %v", err)
+		}
+		if !variables.EvaluateConditions(log, ctx, conditions) {
+			log.V(3).Info("generate.foreach.preconditions not met", "elementIndex", index)
+			continue
+		}
+
+		generation, err := substituteAllInForEach(log, ctx, fe)
+		if err != nil {
+			return genResources, fmt.Errorf("variable substitution failed: %v", err)
+		}
+
+		elementRule := *rule.DeepCopy()
+		elementRule.Generation.ResourceSpec = generation.ResourceSpec
+		elementRule.Generation.RawData = generation.RawData
+		elementRule.Generation.Clone = generation.Clone
+
+		genResource, err := applyRule(log, client, elementRule, policyContext.NewResource, ctx, policyContext.Policy, ur, ruleLabels)
+		if err != nil {
+			return genResources, err
+		}
+		genResources = append(genResources, genResource)
+	}
+	return genResources, nil
+}
+
+// substituteAllInForEach substitutes the variables in the resource declaration of the foreach for the current element
+func substituteAllInForEach(log logr.Logger, ctx context.EvalInterface, fe kyvernov1.ForEachGeneration) (kyvernov1.ForEachGeneration, error) {
+	var generation kyvernov1.ForEachGeneration
+	document, err := variables.DocumentToUntyped(kyvernov1.ForEachGeneration{
+		ResourceSpec: fe.ResourceSpec,
+		RawData:      fe.RawData,
+		Clone:        fe.Clone,
+	})
+	if err != nil {
+		return generation, err
+	}
+
+	document, err = variables.SubstituteAll(log, ctx, document)
+	if err != nil {
+		return generation, err
+	}
+
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return generation, err
+	}
+	err = json.Unmarshal(raw, &generation)
+	return generation, err
+}
+
+func evaluateList(jmesPath string, ctx context.EvalInterface) ([]interface{}, error) {
+	i, err := ctx.Query(jmesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if i == nil {
+		return nil, nil
+	}
+
+	l, ok := i.([]interface{})
+	if !ok {
+		return []interface{}{i}, nil
+	}
+
+	return l, nil
+}
+
+// deleteRemovedResources deletes the resources generated by the foreach or clone list rule for the update request
+// in a previous run, that are not generated anymore as their element or source is removed. The resources of the
+// rule are labeled with the rule name under ruleLabel.
+func deleteRemovedResources(log logr.Logger, client dclient.Interface, ruleLabel, ruleName string, ur kyvernov1beta1.UpdateRequest, genResources []kyvernov1.ResourceSpec) error {
+	for _, previous := range ur.Status.GeneratedResources {
+		if containsResourceSpec(genResources, previous) {
+			continue
+		}
+
+		obj, err := client.GetResource(previous.APIVersion, previous.Kind, previous.Namespace, previous.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		labels := obj.GetLabels()
+		if labels[ruleLabel] != ruleName || labels["policy.kyverno.io/gr-name"] != ur.Name {
+			continue
+		}
+
+		log.V(3).Info("deleting the resource generated for a removed element or source", "kind", previous.Kind, "namespace", previous.Namespace, "name", previous.Name)
+		if err := client.DeleteResource(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsResourceSpec(resources []kyvernov1.ResourceSpec, resource kyvernov1.ResourceSpec) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// addSourceLabel adds the policy name to the label of the source resource,
+// the source resource updates are then synchronized to the generated resources
+func addSourceLabel(client dclient.Interface, source *unstructured.Unstructured, policyName string) error {
+	label := source.GetLabels()
+	if label == nil {
+		label = make(map[string]string)
+	}
+
+	policyNames := label["generate.kyverno.io/clone-policy-name"]
+	if policyNames == "" {
+		policyNames = policyName
+	} else if kyvernoutils.ContainsString(strings.Split(policyNames, ","), policyName) {
+		return nil
+	} else {
+		policyNames = policyNames + "," + policyName
+	}
+
+	label["generate.kyverno.io/clone-policy-name"] = policyNames
+	source.SetLabels(label)
+	_, err := client.UpdateResource(source.GetAPIVersion(), source.GetKind(), source.GetNamespace(), source, false)
+	return err
+}
+
 func manageData(log logr.Logger, apiVersion, kind, namespace, name string, data map[string]interface{}, client dclient.Interface) (map[string]interface{}, ResourceMode, error) {
 	obj, err := client.GetResource(apiVersion, kind, namespace, name)
 	if err != nil {
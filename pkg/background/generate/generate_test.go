@@ -0,0 +1,169 @@
+package generate
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newConfigMap(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace(namespace)
+	configMap.SetName(name)
+	configMap.SetLabels(labels)
+	return configMap
+}
+
+func Test_applyCloneList(t *testing.T) {
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind,
+		newConfigMap("default", "config-a", map[string]string{"allowedToBeCloned": "true"}),
+		newConfigMap("default", "config-b", map[string]string{"allowedToBeCloned": "true"}),
+		newConfigMap("default", "config-c", nil),
+	)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "sync-configmaps"}}
+	rule := kyvernov1.Rule{
+		Name: "sync-configmaps",
+		Generation: kyvernov1.Generation{
+			ResourceSpec: kyvernov1.ResourceSpec{Namespace: "prod"},
+			Synchronize:  true,
+			CloneList: kyvernov1.CloneList{
+				Namespace: "default",
+				Kinds:     []string{"v1/ConfigMap"},
+				Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"allowedToBeCloned": "true"}},
+			},
+		},
+	}
+	trigger := *newConfigMap("", "prod", nil)
+	trigger.SetKind("Namespace")
+
+	ur := kyvernov1beta1.UpdateRequest{ObjectMeta: metav1.ObjectMeta{Name: "ur-1"}}
+	genResources, err := applyCloneList(log.Log, client, rule, trigger, context.NewContext(), policy, ur)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, genResources, []kyvernov1.ResourceSpec{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "prod", Name: "config-a"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "prod", Name: "config-b"},
+	})
+
+	for _, name := range []string{"config-a", "config-b"} {
+		target, err := client.GetResource("v1", "ConfigMap", "prod", name)
+		assert.NilError(t, err)
+		assert.Equal(t, target.GetLabels()["policy.kyverno.io/synchronize"], "enable")
+		assert.Equal(t, target.GetLabels()[cloneListRuleLabel], "sync-configmaps")
+
+		source, err := client.GetResource("v1", "ConfigMap", "default", name)
+		assert.NilError(t, err)
+		assert.Equal(t, source.GetLabels()["generate.kyverno.io/clone-policy-name"], "sync-configmaps")
+	}
+	_, err = client.GetResource("v1", "ConfigMap", "prod", "config-c")
+	assert.Assert(t, err != nil)
+
+	// a labeled source is cloned, the clones of a deleted and an unlabeled source are deleted
+	assert.NilError(t, client.DeleteResource("v1", "ConfigMap", "default", "config-a", false))
+	_, err = client.UpdateResource("v1", "ConfigMap", "default", newConfigMap("default", "config-b", nil), false)
+	assert.NilError(t, err)
+	_, err = client.UpdateResource("v1", "ConfigMap", "default", newConfigMap("default", "config-c", map[string]string{"allowedToBeCloned": "true"}), false)
+	assert.NilError(t, err)
+
+	ur.Status.GeneratedResources = genResources
+	genResources, err = applyCloneList(log.Log, client, rule, trigger, context.NewContext(), policy, ur)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, genResources, []kyvernov1.ResourceSpec{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "prod", Name: "config-c"},
+	})
+	_, err = client.GetResource("v1", "ConfigMap", "prod", "config-c")
+	assert.NilError(t, err)
+	for _, name := range []string{"config-a", "config-b"} {
+		_, err = client.GetResource("v1", "ConfigMap", "prod", name)
+		assert.Assert(t, apierrors.IsNotFound(err))
+	}
+}
+
+func Test_applyForEach(t *testing.T) {
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	var forEach []kyvernov1.ForEachGeneration
+	assert.NilError(t, json.Unmarshal([]byte(`[{
+		"list": "request.object.spec.ports",
+		"preconditions": {"all": [{"key": "{{ element.protocol }}", "operator": "Equals", "value": "TCP"}]},
+		"apiVersion": "v1",
+		"kind": "ConfigMap",
+		"namespace": "{{ request.object.metadata.namespace }}",
+		"name": "{{ request.object.metadata.name }}-{{ element.port }}",
+		"data": {"data": {"port": "{{ to_string(element.port) }}"}}
+	}]`), &forEach))
+	rule := kyvernov1.Rule{
+		Name:       "generate-per-port",
+		Generation: kyvernov1.Generation{Synchronize: true},
+	}
+
+	service := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "nginx", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"port": 80, "protocol": "TCP"},
+				map[string]interface{}{"port": 443, "protocol": "TCP"},
+				map[string]interface{}{"port": 53, "protocol": "UDP"},
+			},
+		},
+	}
+	newPolicyContext := func() *engine.PolicyContext {
+		jsonContext := context.NewContext()
+		assert.NilError(t, jsonContext.AddResource(service))
+		return &engine.PolicyContext{
+			Policy:      &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "generate-per-port"}},
+			NewResource: unstructured.Unstructured{Object: service},
+			JSONContext: jsonContext,
+		}
+	}
+
+	ur := kyvernov1beta1.UpdateRequest{ObjectMeta: metav1.ObjectMeta{Name: "ur-1"}}
+	genResources, err := applyForEach(log.Log, client, rule, forEach, newPolicyContext(), ur)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, genResources, []kyvernov1.ResourceSpec{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "nginx-80"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "nginx-443"},
+	})
+	configMap, err := client.GetResource("v1", "ConfigMap", "default", "nginx-443")
+	assert.NilError(t, err)
+	assert.Equal(t, configMap.GetLabels()[foreachRuleLabel], "generate-per-port")
+	port, _, _ := unstructured.NestedString(configMap.Object, "data", "port")
+	assert.Equal(t, port, "443")
+
+	// the resource generated for a removed element is deleted
+	service["spec"] = map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 80, "protocol": "TCP"}}}
+	ur.Status.GeneratedResources = genResources
+	genResources, err = applyForEach(log.Log, client, rule, forEach, newPolicyContext(), ur)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, genResources, []kyvernov1.ResourceSpec{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "nginx-80"},
+	})
+	_, err = client.GetResource("v1", "ConfigMap", "default", "nginx-443")
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -32,7 +34,8 @@ type Interface interface {
 	Discovery() IDiscovery
 	// SetDiscovery sets the discovery client implementation
 	SetDiscovery(discoveryClient IDiscovery)
-	RawAbsPath(path string) ([]byte, error)
+	// RawAbsPath performs a raw GET or POST request to the API server
+	RawAbsPath(path string, method string, dataReader io.Reader) ([]byte, error)
 	// GetResource returns the resource in unstructured/json format
 	GetResource(apiVersion string, kind string, namespace string, name string, subresources ...string) (*unstructured.Unstructured, error)
 	// PatchResource patches the resource
@@ -141,11 +144,22 @@ func (c *client) GetResource(apiVersion string, kind string, namespace string, n
 	return c.getResourceInterface(apiVersion, kind, namespace).Get(context.TODO(), name, metav1.GetOptions{}, subresources...)
 }
 
-func (c *client) RawAbsPath(path string) ([]byte, error) {
+func (c *client) RawAbsPath(path string, method string, dataReader io.Reader) ([]byte, error) {
 	if c.restClient == nil {
 		return nil, errors.New("rest client not supported")
 	}
-	return c.restClient.Get().AbsPath(path).DoRaw(context.TODO())
+
+	switch method {
+	case "GET":
+		return c.restClient.Get().AbsPath(path).DoRaw(context.TODO())
+	case "POST":
+		if dataReader == nil {
+			dataReader = strings.NewReader("{}")
+		}
+		return c.restClient.Post().AbsPath(path).SetHeader("Content-Type", "application/json").Body(dataReader).DoRaw(context.TODO())
+	default:
+		return nil, fmt.Errorf("method not supported: %s", method)
+	}
 }
 
 // PatchResource patches the resource
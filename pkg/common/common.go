@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/wrappers"
 	enginutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -109,6 +110,10 @@ func ProcessDeletePolicyForCloneGenerateRule(policy kyvernov1.PolicyInterface, c
 }
 
 func updateSourceResource(pName string, rule kyvernov1.Rule, client dclient.Interface, log logr.Logger) error {
+	if !rule.Generation.CloneList.IsEmpty() {
+		return updateSourceResources(pName, rule.Generation.CloneList, client)
+	}
+
 	obj, err := client.GetResource("", rule.Generation.Kind, rule.Generation.Clone.Namespace, rule.Generation.Clone.Name)
 	if err != nil {
 		return errors.Wrapf(err, "source resource %s/%s/%s not found", rule.Generation.Kind, rule.Generation.Clone.Namespace, rule.Generation.Clone.Name)
@@ -126,6 +131,31 @@ func updateSourceResource(pName string, rule kyvernov1.Rule, client dclient.Inte
 	return err
 }
 
+// updateSourceResources removes the policy name from the labels of the source resources of a clone list
+func updateSourceResources(pName string, cloneList kyvernov1.CloneList, client dclient.Interface) error {
+	for _, kind := range cloneList.Kinds {
+		apiVersion, k := kubeutils.GetKindFromGVK(kind)
+		sources, err := client.ListResource(apiVersion, k, cloneList.Namespace, cloneList.Selector)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list source resources %s in namespace %s", kind, cloneList.Namespace)
+		}
+
+		for i := range sources.Items {
+			obj := &sources.Items[i]
+			update, labels := removePolicyFromLabels(pName, obj.GetLabels())
+			if !update {
+				continue
+			}
+
+			obj.SetLabels(labels)
+			if _, err := client.UpdateResource(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func removePolicyFromLabels(pName string, labels map[string]string) (bool, map[string]string) {
 	if len(labels) == 0 {
 		return false, labels
@@ -57,6 +57,10 @@ const (
 	MutatingWebhookServicePath = "/mutate"
 	// ValidatingWebhookServicePath is the path for validation webhook
 	ValidatingWebhookServicePath = "/validate"
+	// DedicatedMutatingWebhookServicePath is the path prefix for the mutation webhooks dedicated to a policy, followed by the policy key
+	DedicatedMutatingWebhookServicePath = "/mutate/policy"
+	// DedicatedValidatingWebhookServicePath is the path prefix for the validation webhooks dedicated to a policy, followed by the policy key
+	DedicatedValidatingWebhookServicePath = "/validate/policy"
 	// PolicyValidatingWebhookServicePath is the path for policy validation webhook(used to validate policy resource)
 	PolicyValidatingWebhookServicePath = "/policyvalidate"
 	// PolicyMutatingWebhookServicePath is the path for policy mutation webhook(used to default)
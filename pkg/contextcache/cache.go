@@ -0,0 +1,254 @@
+package contextcache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubecache "k8s.io/client-go/tools/cache"
+)
+
+// DefaultTTL is the TTL used for cache entries that do not specify one
+const DefaultTTL = 60 * time.Second
+
+// sweepPeriod is the period at which the expired entries are removed
+// and the informers no entry is built from are stopped
+const sweepPeriod = DefaultTTL
+
+// DefaultCache is the cache used by the engine for apiCall and configMap context entries.
+// Caching is disabled when it is nil.
+var DefaultCache Cache
+
+// Cache stores the data retrieved by context entries across rule evaluations
+type Cache interface {
+	// Get returns the cached data for the key, if present and not expired
+	Get(entryType metrics.ContextEntryType, key string) ([]byte, bool)
+	// Add caches the data for the key. The entry is invalidated when the TTL expires or,
+	// if the resource is not nil, when a resource matching it changes.
+	Add(key string, data []byte, ttl time.Duration, resource *Resource)
+	// Invalidate removes the entries built from resources matching the given object
+	Invalidate(gvr schema.GroupVersionResource, namespace, name string)
+}
+
+type entry struct {
+	data     []byte
+	expiry   time.Time
+	resource *Resource
+}
+
+// watcher is an informer used for invalidation, stopped when no entry is built from its resource type
+type watcher struct {
+	informer kubecache.SharedIndexInformer
+	stopCh   chan struct{}
+}
+
+type cache struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+
+	// informers used for invalidation, started on demand per resource type
+	client   dclient.Interface
+	watching map[schema.GroupVersionResource]*watcher
+	stopCh   <-chan struct{}
+
+	metricsConfig metrics.MetricsConfigManager
+	now           func() time.Time
+}
+
+// NewCache creates a new Cache. If the client is nil, entries are only invalidated
+// when their TTL expires. The expired entries are periodically removed until the
+// stop channel is closed.
+func NewCache(client dclient.Interface, metricsConfig metrics.MetricsConfigManager, stopCh <-chan struct{}) Cache {
+	c := &cache{
+		entries:       map[string]*entry{},
+		client:        client,
+		watching:      map[schema.GroupVersionResource]*watcher{},
+		stopCh:        stopCh,
+		metricsConfig: metricsConfig,
+		now:           time.Now,
+	}
+
+	if stopCh != nil {
+		go c.run(sweepPeriod)
+	}
+
+	return c
+}
+
+// APICallKey returns the cache key for an apiCall context entry
+func APICallKey(method, urlPath string, data []byte, jmesPath string) string {
+	return "apiCall:" + method + " " + urlPath + "|" + string(data) + "|" + jmesPath
+}
+
+// ConfigMapKey returns the cache key for a configMap context entry
+func ConfigMapKey(namespace, name string) string {
+	return "configMap:" + namespace + "/" + name
+}
+
+func (c *cache) Get(entryType metrics.ContextEntryType, key string) ([]byte, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && c.now().After(e.expiry) {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		ok = false
+	}
+
+	if ok {
+		c.recordQuery(entryType, metrics.ContextCacheHit)
+		return e.data, true
+	}
+
+	c.recordQuery(entryType, metrics.ContextCacheMiss)
+	return nil, false
+}
+
+func (c *cache) Add(key string, data []byte, ttl time.Duration, resource *Resource) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	c.mu.Lock()
+	c.entries[key] = &entry{data: data, expiry: c.now().Add(ttl), resource: resource}
+	c.mu.Unlock()
+
+	if resource != nil {
+		c.watch(resource.GVR)
+	}
+}
+
+func (c *cache) Invalidate(gvr schema.GroupVersionResource, namespace, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range c.entries {
+		if e.resource != nil && e.resource.matches(gvr, namespace, name) {
+			logger.V(4).Info("invalidating cache entry", "key", key)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// run removes the expired entries every period until the stop channel is closed
+func (c *cache) run(period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.stopCh:
+			c.mu.Lock()
+			for gvr, w := range c.watching {
+				close(w.stopCh)
+				delete(c.watching, gvr)
+			}
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+// sweep removes the expired entries and stops the informers of the resource types
+// that no remaining entry is built from
+func (c *cache) sweep() {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	used := map[schema.GroupVersionResource]struct{}{}
+	for key, e := range c.entries {
+		if now.After(e.expiry) {
+			delete(c.entries, key)
+			continue
+		}
+
+		if e.resource != nil {
+			used[e.resource.GVR] = struct{}{}
+		}
+	}
+
+	for gvr, w := range c.watching {
+		if _, ok := used[gvr]; !ok {
+			logger.V(2).Info("stopping the watch of resources no cache entry is built from", "gvr", gvr.String())
+			close(w.stopCh)
+			delete(c.watching, gvr)
+		}
+	}
+}
+
+// watch starts an informer for the resource type, if not already started,
+// to invalidate cache entries when resources of that type change
+func (c *cache) watch(gvr schema.GroupVersionResource) {
+	if c.client == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.watching[gvr]; ok {
+		c.mu.Unlock()
+		return
+	}
+
+	logger.V(2).Info("watching resources for cache invalidation", "gvr", gvr.String())
+	informer := dynamicinformer.NewFilteredDynamicInformer(c.client.GetDynamicInterface(), gvr, metav1.NamespaceAll, 0, kubecache.Indexers{}, nil).Informer()
+	w := &watcher{informer: informer, stopCh: make(chan struct{})}
+	c.watching[gvr] = w
+	c.mu.Unlock()
+
+	informer.AddEventHandler(kubecache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			// skip the initial list, the cached entries were built after it
+			if informer.HasSynced() {
+				c.invalidateObject(gvr, obj)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			c.invalidateObject(gvr, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(kubecache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
+			c.invalidateObject(gvr, obj)
+		},
+	})
+
+	go informer.Run(w.stopCh)
+}
+
+func (c *cache) invalidateObject(gvr schema.GroupVersionResource, obj interface{}) {
+	key, err := kubecache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(err, "failed to get object key", "gvr", gvr.String())
+		return
+	}
+
+	namespace, name, err := kubecache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "failed to split object key", "key", key)
+		return
+	}
+
+	c.Invalidate(gvr, namespace, name)
+}
+
+func (c *cache) recordQuery(entryType metrics.ContextEntryType, result metrics.ContextCacheResult) {
+	if c.metricsConfig == nil {
+		return
+	}
+
+	c.metricsConfig.RecordContextCacheQueries(entryType, result)
+}
@@ -0,0 +1,173 @@
+package contextcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_ResourceFromURLPath(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	namespaces := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	testCases := []struct {
+		path     string
+		expected *Resource
+	}{
+		{path: "/apis/apps/v1/deployments", expected: &Resource{GVR: deployments}},
+		{path: "/apis/apps/v1/namespaces/default/deployments", expected: &Resource{GVR: deployments, Namespace: "default"}},
+		{path: "/apis/apps/v1/namespaces/default/deployments?labelSelector=app%3Dnginx", expected: &Resource{GVR: deployments, Namespace: "default"}},
+		{path: "/apis/apps/v1/namespaces/default/deployments/nginx", expected: &Resource{GVR: deployments, Namespace: "default", Name: "nginx"}},
+		{path: "/api/v1/namespaces", expected: &Resource{GVR: namespaces}},
+		{path: "/api/v1/namespaces/default", expected: &Resource{GVR: namespaces, Name: "default"}},
+		{path: "/api/v1/namespaces/default/pods/nginx/log", expected: nil},
+		{path: "/version", expected: nil},
+		{path: "/apis/apps/v1", expected: nil},
+	}
+
+	for _, test := range testCases {
+		assert.DeepEqual(t, ResourceFromURLPath(test.path), test.expected)
+	}
+}
+
+func Test_CacheTTL(t *testing.T) {
+	c := NewCache(nil, nil, nil).(*cache)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := APICallKey("GET", "/apis/apps/v1/deployments", nil, "items | length(@)")
+	_, ok := c.Get(metrics.ContextEntryAPICall, key)
+	assert.Assert(t, !ok)
+
+	c.Add(key, []byte("3"), time.Minute, nil)
+	data, ok := c.Get(metrics.ContextEntryAPICall, key)
+	assert.Assert(t, ok)
+	assert.Equal(t, string(data), "3")
+
+	// the JMESPath is part of the key
+	_, ok = c.Get(metrics.ContextEntryAPICall, APICallKey("GET", "/apis/apps/v1/deployments", nil, ""))
+	assert.Assert(t, !ok)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get(metrics.ContextEntryAPICall, key)
+	assert.Assert(t, !ok)
+}
+
+func Test_CacheInvalidate(t *testing.T) {
+	c := NewCache(nil, nil, nil)
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	clusterKey := APICallKey("GET", "/apis/apps/v1/deployments", nil, "")
+	defaultKey := APICallKey("GET", "/apis/apps/v1/namespaces/default/deployments", nil, "")
+	otherKey := APICallKey("GET", "/apis/apps/v1/namespaces/other/deployments", nil, "")
+	configMapKey := ConfigMapKey("default", "config")
+	c.Add(clusterKey, []byte("{}"), 0, ResourceFromURLPath("/apis/apps/v1/deployments"))
+	c.Add(defaultKey, []byte("{}"), 0, ResourceFromURLPath("/apis/apps/v1/namespaces/default/deployments"))
+	c.Add(otherKey, []byte("{}"), 0, ResourceFromURLPath("/apis/apps/v1/namespaces/other/deployments"))
+	c.Add(configMapKey, []byte("{}"), 0, ConfigMapResource("default", "config"))
+
+	c.Invalidate(deployments, "default", "nginx")
+
+	_, ok := c.Get(metrics.ContextEntryAPICall, clusterKey)
+	assert.Assert(t, !ok)
+	_, ok = c.Get(metrics.ContextEntryAPICall, defaultKey)
+	assert.Assert(t, !ok)
+	_, ok = c.Get(metrics.ContextEntryAPICall, otherKey)
+	assert.Assert(t, ok)
+	_, ok = c.Get(metrics.ContextEntryConfigMap, configMapKey)
+	assert.Assert(t, ok)
+
+	c.Invalidate(ConfigMapResource("", "").GVR, "default", "other-config")
+	_, ok = c.Get(metrics.ContextEntryConfigMap, configMapKey)
+	assert.Assert(t, ok)
+
+	c.Invalidate(ConfigMapResource("", "").GVR, "default", "config")
+	_, ok = c.Get(metrics.ContextEntryConfigMap, configMapKey)
+	assert.Assert(t, !ok)
+}
+
+func Test_CacheInformerInvalidation(t *testing.T) {
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace("default")
+	configMap.SetName("config")
+
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, configMap)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := NewCache(client, nil, stopCh).(*cache)
+	key := ConfigMapKey("default", "config")
+	c.Add(key, []byte("{}"), time.Hour, ConfigMapResource("default", "config"))
+	_, ok := c.Get(metrics.ContextEntryConfigMap, key)
+	assert.Assert(t, ok)
+
+	// wait for the informer to sync before changing the config map
+	c.mu.RLock()
+	informer := c.watching[ConfigMapResource("", "").GVR].informer
+	c.mu.RUnlock()
+	for i := 0; i < 100 && !informer.HasSynced(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Assert(t, informer.HasSynced())
+
+	configMap.SetLabels(map[string]string{"updated": "true"})
+	_, err = client.UpdateResource("v1", "ConfigMap", "default", configMap, false)
+	assert.NilError(t, err)
+
+	for i := 0; i < 100 && ok; i++ {
+		time.Sleep(10 * time.Millisecond)
+		_, ok = c.Get(metrics.ContextEntryConfigMap, key)
+	}
+	assert.Assert(t, !ok)
+}
+
+func Test_CacheSweep(t *testing.T) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	})
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	c := NewCache(client, nil, nil).(*cache)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	configMapKey := ConfigMapKey("default", "config")
+	apiCallKey := APICallKey("GET", "/version", nil, "")
+	c.Add(configMapKey, []byte("{}"), time.Minute, ConfigMapResource("default", "config"))
+	c.Add(apiCallKey, []byte("{}"), time.Hour, nil)
+	gvr := ConfigMapResource("", "").GVR
+	w := c.watching[gvr]
+	assert.Assert(t, w != nil)
+
+	// the entries are kept and the informer is running until the config map entry expires
+	c.sweep()
+	assert.Equal(t, len(c.entries), 2)
+	assert.Assert(t, c.watching[gvr] != nil)
+
+	now = now.Add(2 * time.Minute)
+	c.sweep()
+	assert.Equal(t, len(c.entries), 1)
+	_, ok := c.entries[apiCallKey]
+	assert.Assert(t, ok)
+	assert.Equal(t, len(c.watching), 0)
+	select {
+	case <-w.stopCh:
+	default:
+		t.Fatal("the informer of the unused resource type is not stopped")
+	}
+}
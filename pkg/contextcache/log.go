@@ -0,0 +1,5 @@
+package contextcache
+
+import "sigs.k8s.io/controller-runtime/pkg/log"
+
+var logger = log.Log.WithName("contextcache")
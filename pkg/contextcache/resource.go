@@ -0,0 +1,78 @@
+package contextcache
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Resource identifies the Kubernetes resources a cached entry was built from.
+// An empty namespace matches all namespaces, and an empty name matches all
+// resources of the given type.
+type Resource struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+// matches returns true if a change to the given object affects the resource
+func (r *Resource) matches(gvr schema.GroupVersionResource, namespace, name string) bool {
+	if r.GVR != gvr {
+		return false
+	}
+
+	if r.Namespace != "" && r.Namespace != namespace {
+		return false
+	}
+
+	return r.Name == "" || r.Name == name
+}
+
+// ConfigMapResource returns the resource for a ConfigMap
+func ConfigMapResource(namespace, name string) *Resource {
+	return &Resource{
+		GVR:       schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// ResourceFromURLPath returns the resource referenced by a Kubernetes API server
+// URL path (e.g. "/apis/apps/v1/namespaces/default/deployments"), or nil if the
+// path does not reference a resource or a resource list.
+func ResourceFromURLPath(urlPath string) *Resource {
+	if i := strings.Index(urlPath, "?"); i >= 0 {
+		urlPath = urlPath[:i]
+	}
+
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gv = schema.GroupVersion{Version: segments[1]}
+		segments = segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gv = schema.GroupVersion{Group: segments[1], Version: segments[2]}
+		segments = segments[3:]
+	default:
+		return nil
+	}
+
+	var namespace string
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		namespace = segments[1]
+		segments = segments[2:]
+	}
+
+	// subresources are not supported
+	if len(segments) > 2 || segments[0] == "" {
+		return nil
+	}
+
+	resource := &Resource{GVR: gv.WithResource(segments[0]), Namespace: namespace}
+	if len(segments) == 2 {
+		resource.Name = segments[1]
+	}
+
+	return resource
+}
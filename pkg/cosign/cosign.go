@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/kyverno/kyverno/pkg/images"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
@@ -33,38 +34,25 @@ import (
 // ImageSignatureRepository is an alternate signature repository
 var ImageSignatureRepository string
 
-type Options struct {
-	ImageRef             string
-	FetchAttestations    bool
-	Key                  string
-	Cert                 string
-	CertChain            string
-	Roots                string
-	Subject              string
-	Issuer               string
-	AdditionalExtensions map[string]string
-	Annotations          map[string]string
-	Repository           string
-	RekorURL             string
-}
+type CosignError struct{}
 
-type Response struct {
-	Digest     string
-	Statements []map[string]interface{}
+type cosignVerifier struct{}
+
+// NewVerifier returns an ImageVerifier for Cosign signatures and attestations
+func NewVerifier() images.ImageVerifier {
+	return &cosignVerifier{}
 }
 
-type CosignError struct{}
+func (v *cosignVerifier) VerifySignature(opts images.Options) (*images.Response, error) {
+	return verifySignature(opts)
+}
 
-func Verify(opts Options) (*Response, error) {
-	if opts.FetchAttestations {
-		return fetchAttestations(opts)
-	} else {
-		return verifySignature(opts)
-	}
+func (v *cosignVerifier) FetchAttestations(opts images.Options) (*images.Response, error) {
+	return fetchAttestations(opts)
 }
 
 // verifySignature verifies that the image has the expected signatures
-func verifySignature(opts Options) (*Response, error) {
+func verifySignature(opts images.Options) (*images.Response, error) {
 	ref, err := name.ParseReference(opts.ImageRef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse image %s", opts.ImageRef)
@@ -109,10 +97,10 @@ func verifySignature(opts Options) (*Response, error) {
 		return nil, err
 	}
 
-	return &Response{Digest: digest}, nil
+	return &images.Response{Digest: digest}, nil
 }
 
-func buildCosignOptions(opts Options) (*cosign.CheckOpts, error) {
+func buildCosignOptions(opts images.Options) (*cosign.CheckOpts, error) {
 	var remoteOpts []remote.Option
 	var err error
 	ro := options.RegistryOptions{}
@@ -251,7 +239,7 @@ func loadCertChain(pem []byte) ([]*x509.Certificate, error) {
 
 // fetchAttestations retrieves signed attestations and decodes them into in-toto statements
 // https://github.com/in-toto/attestation/blob/main/spec/README.md#statement
-func fetchAttestations(opts Options) (*Response, error) {
+func fetchAttestations(opts images.Options) (*images.Response, error) {
 	cosignOpts, err := buildCosignOptions(opts)
 	if err != nil {
 		return nil, err
@@ -285,7 +273,7 @@ func fetchAttestations(opts Options) (*Response, error) {
 		return nil, err
 	}
 
-	return &Response{Digest: digest, Statements: inTotoStatements}, nil
+	return &images.Response{Digest: digest, Statements: inTotoStatements}, nil
 }
 
 func decodeStatements(sigs []oci.Signature) ([]map[string]interface{}, string, error) {
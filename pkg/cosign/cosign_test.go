@@ -3,6 +3,7 @@ package cosign
 import (
 	"testing"
 
+	"github.com/kyverno/kyverno/pkg/images"
 	"github.com/sigstore/cosign/pkg/cosign"
 	"github.com/sigstore/cosign/pkg/oci"
 	"gotest.tools/assert"
@@ -64,7 +65,7 @@ func TestCosignPayload(t *testing.T) {
 }
 
 func TestCosignKeyless(t *testing.T) {
-	opts := Options{
+	opts := images.Options{
 		ImageRef: "ghcr.io/jimbugwadia/pause2",
 		Issuer:   "https://github.com/",
 		Subject:  "jim",
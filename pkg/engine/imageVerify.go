@@ -16,6 +16,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	engineUtils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/notary"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
@@ -278,11 +280,11 @@ func (iv *imageVerifier) verifyImage(imageVerify kyvernov1.ImageVerification, im
 		return ruleResponse(*iv.rule, response.ImageVerify, msg, response.RuleStatusError, nil), ""
 	}
 
-	var cosignResponse *cosign.Response
+	var verifyResponse *images.Response
 	for i, attestorSet := range imageVerify.Attestors {
 		var err error
 		path := fmt.Sprintf(".attestors[%d]", i)
-		cosignResponse, err = iv.verifyAttestorSet(attestorSet, imageVerify, imageInfo, path)
+		verifyResponse, err = iv.verifyAttestorSet(attestorSet, imageVerify, imageInfo, path)
 		if err != nil {
 			iv.logger.Error(err, "failed to verify signature")
 			msg := fmt.Sprintf("failed to verify signature for %s: %s", image, err.Error())
@@ -297,17 +299,17 @@ func (iv *imageVerifier) verifyImage(imageVerify kyvernov1.ImageVerification, im
 		}
 	}
 
-	if cosignResponse == nil {
+	if verifyResponse == nil {
 		return ruleError(iv.rule, response.ImageVerify, "invalid response", fmt.Errorf("nil")), ""
 	}
 
 	msg := fmt.Sprintf("verified image signatures for %s", image)
-	return ruleResponse(*iv.rule, response.ImageVerify, msg, response.RuleStatusPass, nil), cosignResponse.Digest
+	return ruleResponse(*iv.rule, response.ImageVerify, msg, response.RuleStatusPass, nil), verifyResponse.Digest
 }
 
 func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, imageVerify kyvernov1.ImageVerification,
 	imageInfo apiutils.ImageInfo, path string,
-) (*cosign.Response, error) {
+) (*images.Response, error) {
 	var errorList []error
 	verifiedCount := 0
 	attestorSet = expandStaticKeys(attestorSet)
@@ -316,7 +318,7 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 
 	for i, a := range attestorSet.Entries {
 		var entryError error
-		var cosignResp *cosign.Response
+		var verifyResp *images.Response
 		attestorPath := fmt.Sprintf("%s.entries[%d]", path, i)
 
 		if a.Attestor != nil {
@@ -325,13 +327,18 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 				entryError = errors.Wrapf(err, "failed to unmarshal nested attestor %s", attestorPath)
 			} else {
 				attestorPath += ".attestor"
-				cosignResp, entryError = iv.verifyAttestorSet(*nestedAttestorSet, imageVerify, imageInfo, attestorPath)
+				verifyResp, entryError = iv.verifyAttestorSet(*nestedAttestorSet, imageVerify, imageInfo, attestorPath)
 			}
 		} else {
 			opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image)
-			cosignResp, entryError = cosign.Verify(*opts)
-			if entryError == nil && opts.FetchAttestations {
-				entryError = iv.verifyAttestations(cosignResp.Statements, imageVerify, imageInfo)
+			verifier := getVerifier(imageVerify.Type)
+			if opts.FetchAttestations {
+				verifyResp, entryError = verifier.FetchAttestations(*opts)
+				if entryError == nil {
+					entryError = iv.verifyAttestations(verifyResp.Statements, imageVerify, imageInfo)
+				}
+			} else {
+				verifyResp, entryError = verifier.VerifySignature(*opts)
 			}
 
 			if entryError != nil {
@@ -343,7 +350,7 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 			verifiedCount++
 			if verifiedCount >= requiredCount {
 				iv.logger.V(2).Info("image verification succeeded", "verifiedCount", verifiedCount, "requiredCount", requiredCount)
-				return cosignResp, nil
+				return verifyResp, nil
 			}
 		} else {
 			errorList = append(errorList, entryError)
@@ -407,9 +414,18 @@ func getRequiredCount(as kyvernov1.AttestorSet) int {
 	return *as.Count
 }
 
-func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageVerify kyvernov1.ImageVerification, image string) (*cosign.Options, string) {
+// getVerifier returns the ImageVerifier for the signature format of the verifyImages rule
+func getVerifier(verificationType kyvernov1.ImageVerificationType) images.ImageVerifier {
+	if verificationType == kyvernov1.NotaryV2 {
+		return notary.NewVerifier()
+	}
+
+	return cosign.NewVerifier()
+}
+
+func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageVerify kyvernov1.ImageVerification, image string) (*images.Options, string) {
 	path := ""
-	opts := &cosign.Options{
+	opts := &images.Options{
 		ImageRef:    image,
 		Repository:  imageVerify.Repository,
 		Annotations: imageVerify.Annotations,
@@ -545,6 +545,56 @@ func Test_ExpandKeys(t *testing.T) {
 	assert.Equal(t, 3, len(as.Entries))
 }
 
+func Test_RequiredCount(t *testing.T) {
+	as := expandStaticKeys(createStaticKeyAttestorSet(testOtherKey + testOtherKey + testOtherKey))
+	assert.Equal(t, 3, getRequiredCount(as))
+
+	two := 2
+	as.Count = &two
+	assert.Equal(t, 2, getRequiredCount(as))
+}
+
+var testInvalidKey = `-----BEGIN PUBLIC KEY-----\ninvalid\n-----END PUBLIC KEY-----\n`
+
+func Test_SignaturesCountOfAttestors(t *testing.T) {
+	image := "ghcr.io/kyverno/test-verify-image:signed"
+	defer cosign.ClearMock()
+
+	testCases := []struct {
+		name   string
+		keys   string
+		count  string
+		status response.RuleStatus
+		failed []string
+	}{
+		{name: "count attestors verified", keys: testVerifyImageKey + testOtherKey, count: "2", status: response.RuleStatusPass},
+		{name: "all attestors required", keys: testVerifyImageKey + testOtherKey, count: "0", status: response.RuleStatusFail, failed: []string{".entries[2].keys"}},
+		{name: "one attestor less than count verified", keys: testVerifyImageKey + testInvalidKey, count: "2", status: response.RuleStatusFail, failed: []string{".entries[1].keys", ".entries[2].keys"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := cosign.SetMock(image, signaturePayloads)
+			assert.NilError(t, err)
+
+			policy := strings.Replace(testSampleMultipleKeyPolicy, "KEY1", tc.keys, -1)
+			policy = strings.Replace(policy, "KEY2", testInvalidKey, -1)
+			policy = strings.Replace(policy, "COUNT", tc.count, -1)
+			policyContext := buildContext(t, policy, testSampleResource, "")
+			engineResponse, _ := VerifyAndPatchImages(policyContext)
+			assert.Equal(t, len(engineResponse.PolicyResponse.Rules), 1)
+			rule := engineResponse.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status, tc.status, rule.Message)
+			for _, failed := range tc.failed {
+				assert.Assert(t, strings.Contains(rule.Message, failed+": failed to load public key from PEM"), rule.Message)
+			}
+			if tc.status == response.RuleStatusPass {
+				assert.Assert(t, !strings.Contains(rule.Message, "failed to load public key"), rule.Message)
+			}
+		})
+	}
+}
+
 func createStaticKeyAttestorSet(s string) kyverno.AttestorSet {
 	return kyverno.AttestorSet{
 		Entries: []kyverno.Attestor{
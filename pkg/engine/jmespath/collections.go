@@ -0,0 +1,347 @@
+package jmespath
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func jpLookup(arguments []interface{}) (interface{}, error) {
+	switch collection := arguments[0].(type) {
+	case map[string]interface{}:
+		key, ok := arguments[1].(string)
+		if !ok {
+			return nil, fmt.Errorf(invalidArgumentTypeError, lookup, 2, "String")
+		}
+
+		return collection[key], nil
+	case []interface{}:
+		index, ok := arguments[1].(float64)
+		if !ok || index != float64(int(index)) {
+			return nil, fmt.Errorf(invalidArgumentTypeError, lookup, 2, "Integer")
+		}
+
+		if index < 0 || int(index) >= len(collection) {
+			return nil, nil
+		}
+
+		return collection[int(index)], nil
+	default:
+		return nil, fmt.Errorf(invalidArgumentTypeError, lookup, 1, "Object or Array")
+	}
+}
+
+func jpUnique(arguments []interface{}) (interface{}, error) {
+	arr, err := validateArg(unique, arguments, 0, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, item := range arr.Interface().([]interface{}) {
+		if !containsValue(result, item) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+func jpFlattenObject(arguments []interface{}) (interface{}, error) {
+	obj, err := validateArg(flattenObject, arguments, 0, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	flatten("", obj.Interface().(map[string]interface{}), result)
+	return result, nil
+}
+
+// flatten adds the values of nested objects to the result, with keys joined by '.'
+func flatten(prefix string, obj map[string]interface{}, result map[string]interface{}) {
+	for k, v := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+			flatten(key, nested, result)
+		} else {
+			result[key] = v
+		}
+	}
+}
+
+func jpMerge(arguments []interface{}) (interface{}, error) {
+	obj1, err := validateArg(merge, arguments, 0, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+
+	obj2, err := validateArg(merge, arguments, 1, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+
+	return deepMerge(obj1.Interface().(map[string]interface{}), obj2.Interface().(map[string]interface{})), nil
+}
+
+// deepMerge merges the second object into the first one, values of the second object take precedence
+func deepMerge(obj1, obj2 map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(obj1))
+	for k, v := range obj1 {
+		result[k] = v
+	}
+
+	for k, v2 := range obj2 {
+		m1, ok1 := result[k].(map[string]interface{})
+		m2, ok2 := v2.(map[string]interface{})
+		if ok1 && ok2 {
+			result[k] = deepMerge(m1, m2)
+		} else {
+			result[k] = v2
+		}
+	}
+
+	return result
+}
+
+// setArguments validates that the arguments of a set function are either two arrays or two objects
+func setArguments(f string, arguments []interface{}) (reflect.Kind, error) {
+	kind := reflect.ValueOf(arguments[0]).Kind()
+	if kind != reflect.Slice && kind != reflect.Map {
+		return kind, fmt.Errorf(invalidArgumentTypeError, f, 1, "Array or Object")
+	}
+
+	if _, err := validateArg(f, arguments, 1, kind); err != nil {
+		return kind, err
+	}
+
+	return kind, nil
+}
+
+func jpDifference(arguments []interface{}) (interface{}, error) {
+	kind, err := setArguments(difference, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == reflect.Map {
+		obj1, obj2 := arguments[0].(map[string]interface{}), arguments[1].(map[string]interface{})
+		result := map[string]interface{}{}
+		for k, v := range obj1 {
+			if v2, ok := obj2[k]; !ok || !reflect.DeepEqual(v, v2) {
+				result[k] = v
+			}
+		}
+
+		return result, nil
+	}
+
+	arr1, arr2 := arguments[0].([]interface{}), arguments[1].([]interface{})
+	result := []interface{}{}
+	for _, v := range arr1 {
+		if !containsValue(arr2, v) && !containsValue(result, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+func jpIntersection(arguments []interface{}) (interface{}, error) {
+	kind, err := setArguments(intersection, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == reflect.Map {
+		obj1, obj2 := arguments[0].(map[string]interface{}), arguments[1].(map[string]interface{})
+		result := map[string]interface{}{}
+		for k, v := range obj1 {
+			if v2, ok := obj2[k]; ok && reflect.DeepEqual(v, v2) {
+				result[k] = v
+			}
+		}
+
+		return result, nil
+	}
+
+	arr1, arr2 := arguments[0].([]interface{}), arguments[1].([]interface{})
+	result := []interface{}{}
+	for _, v := range arr1 {
+		if containsValue(arr2, v) && !containsValue(result, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+func jpUnion(arguments []interface{}) (interface{}, error) {
+	kind, err := setArguments(union, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == reflect.Map {
+		obj1, obj2 := arguments[0].(map[string]interface{}), arguments[1].(map[string]interface{})
+		result := map[string]interface{}{}
+		for k, v := range obj2 {
+			result[k] = v
+		}
+
+		for k, v := range obj1 {
+			result[k] = v
+		}
+
+		return result, nil
+	}
+
+	result := []interface{}{}
+	for _, arr := range [][]interface{}{arguments[0].([]interface{}), arguments[1].([]interface{})} {
+		for _, v := range arr {
+			if !containsValue(result, v) {
+				result = append(result, v)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func jpSum(arguments []interface{}) (interface{}, error) {
+	arr, err := validateArg(sum, arguments, 0, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+
+	items := arr.Interface().([]interface{})
+	if len(items) == 0 {
+		return 0.0, nil
+	}
+
+	result := items[0]
+	for _, item := range items[1:] {
+		result, err = jpAdd([]interface{}{result, item})
+		if err != nil {
+			return nil, fmt.Errorf(genericError, sum, "invalid operands")
+		}
+	}
+
+	return result, nil
+}
+
+func jpPathJoin(arguments []interface{}) (interface{}, error) {
+	arr, err := validateArg(pathJoin, arguments, 0, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := []string{}
+	for _, item := range arr.Interface().([]interface{}) {
+		elem, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf(genericError, pathJoin, "all elements must be strings")
+		}
+
+		elems = append(elems, elem)
+	}
+
+	return path.Join(elems...), nil
+}
+
+func jpToBoolean(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(toBoolean, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(str.String()) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return nil, fmt.Errorf(genericError, toBoolean, fmt.Sprintf("lowercase argument must be 'true' or 'false', found '%s'", str.String()))
+	}
+}
+
+func jpSortByKey(arguments []interface{}) (interface{}, error) {
+	arr, err := validateArg(sortByKey, arguments, 0, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := validateArg(sortByKey, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	items := arr.Interface().([]interface{})
+	result := make([]interface{}, len(items))
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(genericError, sortByKey, "all elements must be objects")
+		}
+
+		result[i] = obj
+		values[i] = obj[key.String()]
+	}
+
+	var sortErr error
+	sort.Stable(byValue{items: result, values: values, err: &sortErr})
+	if sortErr != nil {
+		return nil, fmt.Errorf(genericError, sortByKey, sortErr.Error())
+	}
+
+	return result, nil
+}
+
+// byValue sorts items by the corresponding string or number values
+type byValue struct {
+	items  []interface{}
+	values []interface{}
+	err    *error
+}
+
+func (b byValue) Len() int {
+	return len(b.items)
+}
+
+func (b byValue) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+func (b byValue) Less(i, j int) bool {
+	switch vi := b.values[i].(type) {
+	case string:
+		if vj, ok := b.values[j].(string); ok {
+			return vi < vj
+		}
+	case float64:
+		if vj, ok := b.values[j].(float64); ok {
+			return vi < vj
+		}
+	}
+
+	*b.err = fmt.Errorf("values must all be strings or all be numbers")
+	return false
+}
+
+func containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_CollectionFunctions(t *testing.T) {
+	data := []byte(`{
+		"object": {"metadata": {"labels": {"app": "nginx", "team": "web", "env": "prod"}}},
+		"oldObject": {"metadata": {"labels": {"app": "nginx", "team": "infra"}}}
+	}`)
+
+	var resource interface{}
+	assert.NilError(t, json.Unmarshal(data, &resource))
+
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+	}{
+		{
+			test:           "lookup(object.metadata.labels, 'team')",
+			expectedResult: "web",
+		},
+		{
+			test:           "lookup(object.metadata.labels, 'missing')",
+			expectedResult: nil,
+		},
+		{
+			test:           "lookup(['a', 'b', 'c'], `1`)",
+			expectedResult: "b",
+		},
+		{
+			test:           "lookup(['a', 'b', 'c'], `5`)",
+			expectedResult: nil,
+		},
+		{
+			test:           "unique(['a', 'b', 'a', 'c', 'b'])",
+			expectedResult: []interface{}{"a", "b", "c"},
+		},
+		{
+			test:           "flatten_object(object)",
+			expectedResult: map[string]interface{}{"metadata.labels.app": "nginx", "metadata.labels.team": "web", "metadata.labels.env": "prod"},
+		},
+		{
+			test: "merge(oldObject, object).metadata.labels",
+			expectedResult: map[string]interface{}{
+				"app": "nginx", "team": "web", "env": "prod",
+			},
+		},
+		{
+			test:           "merge({a: {b: 'c', d: 'e'}}, {a: {b: 'f'}})",
+			expectedResult: map[string]interface{}{"a": map[string]interface{}{"b": "f", "d": "e"}},
+		},
+		{
+			test:           "difference(object.metadata.labels, oldObject.metadata.labels)",
+			expectedResult: map[string]interface{}{"team": "web", "env": "prod"},
+		},
+		{
+			test:           "intersection(object.metadata.labels, oldObject.metadata.labels)",
+			expectedResult: map[string]interface{}{"app": "nginx"},
+		},
+		{
+			test:           "union(oldObject.metadata.labels, object.metadata.labels)",
+			expectedResult: map[string]interface{}{"app": "nginx", "team": "infra", "env": "prod"},
+		},
+		{
+			test:           "difference(['a', 'b', 'c'], ['b'])",
+			expectedResult: []interface{}{"a", "c"},
+		},
+		{
+			test:           "intersection(['a', 'b', 'c'], ['c', 'b', 'd'])",
+			expectedResult: []interface{}{"b", "c"},
+		},
+		{
+			test:           "union(['a', 'b'], ['b', 'c'])",
+			expectedResult: []interface{}{"a", "b", "c"},
+		},
+		{
+			test:           "sum([`1`, `2`, `3.5`])",
+			expectedResult: 6.5,
+		},
+		{
+			test:           "sum(['1Gi', '512Mi'])",
+			expectedResult: "1536Mi",
+		},
+		{
+			test:           "sum(['1h', '90s'])",
+			expectedResult: "1h1m30s",
+		},
+		{
+			test:           "sum(`[]`)",
+			expectedResult: 0.0,
+		},
+		{
+			test:           "path_join(['/var', 'lib', '../run', 'kyverno'])",
+			expectedResult: "/var/run/kyverno",
+		},
+		{
+			test:           "to_boolean('True')",
+			expectedResult: true,
+		},
+		{
+			test:           "to_boolean('false')",
+			expectedResult: false,
+		},
+		{
+			test:           "sort_by_key([{name: 'c'}, {name: 'a'}, {name: 'b'}], 'name')[].name",
+			expectedResult: []interface{}{"a", "b", "c"},
+		},
+		{
+			test:           "sort_by_key([{n: `3`}, {n: `1`}, {n: `2`}], 'n')[].n",
+			expectedResult: []interface{}{1.0, 2.0, 3.0},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search(resource)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_CollectionFunctionErrors(t *testing.T) {
+	testCases := []struct {
+		test          string
+		expectedError string
+	}{
+		{
+			test:          "lookup(['a'], 'b')",
+			expectedError: "JMESPath function 'lookup': 2 argument is expected of Integer type",
+		},
+		{
+			test:          "difference(['a'], {a: 'b'})",
+			expectedError: "JMESPath function 'difference': 2 argument is expected of",
+		},
+		{
+			test:          "sum([`1`, 'one'])",
+			expectedError: "JMESPath function 'sum': invalid operands",
+		},
+		{
+			test:          "to_boolean('yes')",
+			expectedError: "JMESPath function 'to_boolean': lowercase argument must be 'true' or 'false', found 'yes'",
+		},
+		{
+			test:          "sort_by_key([{n: `1`}, {n: 'a'}], 'n')",
+			expectedError: "JMESPath function 'sort_by_key': values must all be strings or all be numbers",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			_, err = query.Search("")
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
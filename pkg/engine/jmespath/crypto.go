@@ -0,0 +1,194 @@
+package jmespath
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+func jpSha256(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(sha256Hash, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256([]byte(str.String()))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func jpSha512(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(sha512Hash, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha512.Sum512([]byte(str.String()))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func jpMd5(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(md5Hash, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := md5.Sum([]byte(str.String())) //nolint:gosec
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// certificate is the decoded form of an X.509 certificate returned by x509_decode
+type certificate struct {
+	Version            int       `json:"version"`
+	SerialNumber       string    `json:"serialNumber"`
+	Subject            certName  `json:"subject"`
+	Issuer             certName  `json:"issuer"`
+	NotBefore          time.Time `json:"notBefore"`
+	NotAfter           time.Time `json:"notAfter"`
+	DNSNames           []string  `json:"dnsNames"`
+	IPAddresses        []string  `json:"ipAddresses"`
+	EmailAddresses     []string  `json:"emailAddresses"`
+	URIs               []string  `json:"uris"`
+	IsCA               bool      `json:"isCA"`
+	KeyUsage           []string  `json:"keyUsage"`
+	ExtKeyUsage        []string  `json:"extKeyUsage"`
+	SignatureAlgorithm string    `json:"signatureAlgorithm"`
+	PublicKeyAlgorithm string    `json:"publicKeyAlgorithm"`
+}
+
+type certName struct {
+	CommonName         string   `json:"commonName"`
+	Organization       []string `json:"organization"`
+	OrganizationalUnit []string `json:"organizationalUnit"`
+	Country            []string `json:"country"`
+	Locality           []string `json:"locality"`
+	Province           []string `json:"province"`
+	String             string   `json:"string"`
+}
+
+var keyUsages = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+	{x509.KeyUsageContentCommitment, "ContentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+	{x509.KeyUsageCertSign, "CertSign"},
+	{x509.KeyUsageCRLSign, "CRLSign"},
+	{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+	{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+}
+
+var extKeyUsages = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:             "Any",
+	x509.ExtKeyUsageServerAuth:      "ServerAuth",
+	x509.ExtKeyUsageClientAuth:      "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:     "CodeSigning",
+	x509.ExtKeyUsageEmailProtection: "EmailProtection",
+	x509.ExtKeyUsageIPSECEndSystem:  "IPSECEndSystem",
+	x509.ExtKeyUsageIPSECTunnel:     "IPSECTunnel",
+	x509.ExtKeyUsageIPSECUser:       "IPSECUser",
+	x509.ExtKeyUsageTimeStamping:    "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:     "OCSPSigning",
+}
+
+func jpX509Decode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(x509Decode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(str.String()))
+	if block == nil {
+		return nil, fmt.Errorf(genericError, x509Decode, "failed to decode PEM block")
+	}
+
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf(genericError, x509Decode, "PEM block type must be CERTIFICATE, found "+block.Type)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, x509Decode, err.Error())
+	}
+
+	decoded := certificate{
+		Version:            cert.Version,
+		SerialNumber:       cert.SerialNumber.String(),
+		Subject:            newCertName(cert.Subject),
+		Issuer:             newCertName(cert.Issuer),
+		NotBefore:          cert.NotBefore.UTC(),
+		NotAfter:           cert.NotAfter.UTC(),
+		DNSNames:           emptyIfNil(cert.DNSNames),
+		EmailAddresses:     emptyIfNil(cert.EmailAddresses),
+		IPAddresses:        []string{},
+		URIs:               []string{},
+		IsCA:               cert.IsCA,
+		KeyUsage:           []string{},
+		ExtKeyUsage:        []string{},
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		PublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+	}
+
+	for _, ip := range cert.IPAddresses {
+		decoded.IPAddresses = append(decoded.IPAddresses, ip.String())
+	}
+
+	for _, uri := range cert.URIs {
+		decoded.URIs = append(decoded.URIs, uri.String())
+	}
+
+	for _, ku := range keyUsages {
+		if cert.KeyUsage&ku.usage != 0 {
+			decoded.KeyUsage = append(decoded.KeyUsage, ku.name)
+		}
+	}
+
+	for _, eku := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsages[eku]; ok {
+			decoded.ExtKeyUsage = append(decoded.ExtKeyUsage, name)
+		}
+	}
+
+	// convert to a generic JSON object so that the result can be queried
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, x509Decode, err.Error())
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf(genericError, x509Decode, err.Error())
+	}
+
+	return result, nil
+}
+
+func newCertName(name pkix.Name) certName {
+	return certName{
+		CommonName:         name.CommonName,
+		Organization:       emptyIfNil(name.Organization),
+		OrganizationalUnit: emptyIfNil(name.OrganizationalUnit),
+		Country:            emptyIfNil(name.Country),
+		Locality:           emptyIfNil(name.Locality),
+		Province:           emptyIfNil(name.Province),
+		String:             name.String(),
+	}
+}
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
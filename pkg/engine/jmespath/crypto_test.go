@@ -0,0 +1,102 @@
+package jmespath
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_Hashes(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult string
+	}{
+		{
+			test:           "sha256('kyverno')",
+			expectedResult: "6900ead2739f4d80767db3f097b8c7e352c395dac245945161cb7d5e3f8438b3",
+		},
+		{
+			test:           "sha512('kyverno')",
+			expectedResult: "1364533abbf4b1f4a774bc44b3b45d71ffc3f52ec70d2923bbc268954e36864c57948b28ebdba9df1bcd50d58ecb6f1b5cf93afd53688890bdc251bd99da9bf6",
+		},
+		{
+			test:           "md5('kyverno')",
+			expectedResult: "9638dd54a7735b1d741a6e202444d186",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_X509Decode(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "test.kyverno.io", Organization: []string{"Kyverno"}},
+		NotBefore:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		DNSNames:     []string{"test.kyverno.io", "*.test.kyverno.io"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+	}{
+		{test: "x509_decode(@).subject.commonName", expectedResult: "test.kyverno.io"},
+		{test: "x509_decode(@).subject.organization", expectedResult: []interface{}{"Kyverno"}},
+		{test: "x509_decode(@).issuer.string", expectedResult: "CN=test.kyverno.io,O=Kyverno"},
+		{test: "x509_decode(@).serialNumber", expectedResult: "42"},
+		{test: "x509_decode(@).notBefore", expectedResult: "2022-01-01T00:00:00Z"},
+		{test: "x509_decode(@).notAfter", expectedResult: "2023-01-01T00:00:00Z"},
+		{test: "x509_decode(@).dnsNames", expectedResult: []interface{}{"test.kyverno.io", "*.test.kyverno.io"}},
+		{test: "x509_decode(@).ipAddresses", expectedResult: []interface{}{"10.0.0.1"}},
+		{test: "x509_decode(@).keyUsage", expectedResult: []interface{}{"DigitalSignature", "KeyEncipherment"}},
+		{test: "x509_decode(@).extKeyUsage", expectedResult: []interface{}{"ServerAuth"}},
+		{test: "x509_decode(@).isCA", expectedResult: false},
+		{test: "time_before(x509_decode(@).notAfter, '2022-06-01T00:00:00Z')", expectedResult: false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search(string(certPEM))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, res, tc.expectedResult)
+		})
+	}
+
+	query, err := New("x509_decode('not a certificate')")
+	assert.NilError(t, err)
+	_, err = query.Search("")
+	assert.ErrorContains(t, err, "failed to decode PEM block")
+}
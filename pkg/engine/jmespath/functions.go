@@ -57,7 +57,23 @@ var (
 	modulo                 = "modulo"
 	base64Decode           = "base64_decode"
 	base64Encode           = "base64_encode"
+	sha256Hash             = "sha256"
+	sha512Hash             = "sha512"
+	md5Hash                = "md5"
+	x509Decode             = "x509_decode"
+	random                 = "random"
+	uuidGenerate           = "uuid"
 	timeSince              = "time_since"
+	timeNow                = "time_now"
+	timeNowUtc             = "time_now_utc"
+	timeAdd                = "time_add"
+	timeParse              = "time_parse"
+	timeToCron             = "time_to_cron"
+	timeBefore             = "time_before"
+	timeAfter              = "time_after"
+	timeBetween            = "time_between"
+	timeTruncate           = "time_truncate"
+	timeDiff               = "time_diff"
 	pathCanonicalize       = "path_canonicalize"
 	truncate               = "truncate"
 	semverCompare          = "semver_compare"
@@ -65,6 +81,17 @@ var (
 	parseYAML              = "parse_yaml"
 	items                  = "items"
 	objectFromLists        = "object_from_lists"
+	lookup                 = "lookup"
+	unique                 = "unique"
+	flattenObject          = "flatten_object"
+	merge                  = "merge"
+	difference             = "difference"
+	intersection           = "intersection"
+	union                  = "union"
+	sum                    = "sum"
+	pathJoin               = "path_join"
+	toBoolean              = "to_boolean"
+	sortByKey              = "sort_by_key"
 )
 
 const (
@@ -332,6 +359,69 @@ func GetFunctions() []*FunctionEntry {
 			},
 			ReturnType: []JpType{JpString},
 		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sha256Hash,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpSha256,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the hex encoded SHA-256 hash of a string",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sha512Hash,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpSha512,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the hex encoded SHA-512 hash of a string",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: md5Hash,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpMd5,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the hex encoded MD5 hash of a string",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: x509Decode,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpX509Decode,
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "decodes a PEM encoded X.509 certificate to an object with its subject, issuer, SANs, validity and key usage",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: random,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpRandom,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "generates a random string matching a regular expression; ex. \"random('[a-z0-9]{6}')\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name:    uuidGenerate,
+				Handler: jpUUID,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "generates a random UUID (version 4)",
+		},
 		{
 			Entry: &gojmespath.FunctionEntry{
 				Name: timeSince,
@@ -344,6 +434,118 @@ func GetFunctions() []*FunctionEntry {
 			},
 			ReturnType: []JpType{JpString},
 		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name:    timeNow,
+				Handler: jpTimeNow,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the current time in RFC 3339 format",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name:    timeNowUtc,
+				Handler: jpTimeNowUtc,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the current UTC time in RFC 3339 format",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeAdd,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeAdd,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "adds a duration to an RFC 3339 time; ex. \"time_add('2021-01-02T15:04:05Z', '3h')\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeParse,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeParse,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "parses a time with a Go time layout and returns it in RFC 3339 format; ex. \"time_parse('Mon Jan 02 2006', 'Sat Jan 02 2021')\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeToCron,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeToCron,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "converts an RFC 3339 time to a cron expression in UTC",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeBefore,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeBefore,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if the first RFC 3339 time is before the second one",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeAfter,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeAfter,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if the first RFC 3339 time is after the second one",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeBetween,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeBetween,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if an RFC 3339 time is between a start and an end time (exclusive)",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeTruncate,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeTruncate,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "rounds an RFC 3339 time down to a multiple of a duration; ex. \"time_truncate('2021-01-02T15:04:05Z', '1h')\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeDiff,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeDiff,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "returns the duration between two RFC 3339 times",
+		},
 		{
 			Entry: &gojmespath.FunctionEntry{
 				Name: pathCanonicalize,
@@ -424,6 +626,133 @@ func GetFunctions() []*FunctionEntry {
 			ReturnType: []JpType{JpObject},
 			Note:       "converts a pair of lists containing keys and values to an object",
 		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: lookup,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpObject, JpArray}},
+					{Types: []JpType{JpString, JpNumber}},
+				},
+				Handler: jpLookup,
+			},
+			ReturnType: []JpType{JpAny},
+			Note:       "returns the value of a key in an object or the element at an index in an array, null if not found",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: unique,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray}},
+				},
+				Handler: jpUnique,
+			},
+			ReturnType: []JpType{JpArray},
+			Note:       "removes duplicate elements from an array, keeping the first occurrence",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: flattenObject,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpObject}},
+				},
+				Handler: jpFlattenObject,
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "flattens nested objects, joining keys with '.'; ex. \"flatten_object({a: {b: 'c'}})\" returns {\"a.b\": \"c\"}",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: merge,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpObject}},
+					{Types: []JpType{JpObject}},
+				},
+				Handler: jpMerge,
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "deep merges two objects, values of the second object take precedence",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: difference,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray, JpObject}},
+					{Types: []JpType{JpArray, JpObject}},
+				},
+				Handler: jpDifference,
+			},
+			ReturnType: []JpType{JpArray, JpObject},
+			Note:       "returns the elements of the first array, or the key/value pairs of the first object, not present in the second one",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: intersection,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray, JpObject}},
+					{Types: []JpType{JpArray, JpObject}},
+				},
+				Handler: jpIntersection,
+			},
+			ReturnType: []JpType{JpArray, JpObject},
+			Note:       "returns the elements, or key/value pairs, present in both arrays or objects",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: union,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray, JpObject}},
+					{Types: []JpType{JpArray, JpObject}},
+				},
+				Handler: jpUnion,
+			},
+			ReturnType: []JpType{JpArray, JpObject},
+			Note:       "returns the elements, or key/value pairs, present in either array or object; for objects, values of the first object take precedence",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sum,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray}},
+				},
+				Handler: jpSum,
+			},
+			ReturnType: []JpType{JpNumber, JpString},
+			Note:       "sums the numbers, quantities or durations of an array, an empty array sums to 0",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: pathJoin,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArrayString}},
+				},
+				Handler: jpPathJoin,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "joins path elements into a single path; ex. \"path_join(['/var', 'lib', 'kyverno'])\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: toBoolean,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpToBoolean,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "converts the strings 'true' and 'false' (case insensitive) to a boolean",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sortByKey,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpSortByKey,
+			},
+			ReturnType: []JpType{JpArray},
+			Note:       "sorts an array of objects by the value of a key, values must all be strings or all be numbers",
+		},
 	}
 }
 
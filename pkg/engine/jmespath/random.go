@@ -0,0 +1,159 @@
+package jmespath
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxRepeat is the maximum number of repetitions generated for unbounded
+// regular expression operators such as '*' and '+'
+const maxRepeat = 10
+
+func jpRandom(arguments []interface{}) (interface{}, error) {
+	pattern, err := validateArg(random, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	if pattern.String() == "" {
+		return nil, fmt.Errorf(genericError, random, "no pattern provided")
+	}
+
+	re, err := syntax.Parse(pattern.String(), syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, random, err.Error())
+	}
+
+	var sb strings.Builder
+	if err := generate(&sb, re.Simplify()); err != nil {
+		return nil, fmt.Errorf(genericError, random, err.Error())
+	}
+
+	return sb.String(), nil
+}
+
+func jpUUID(arguments []interface{}) (interface{}, error) {
+	return uuid.New().String(), nil
+}
+
+// generate writes a random string matching the regular expression
+func generate(sb *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("pattern %s cannot match any string", re.String())
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		return generateFromClass(sb, re.Rune)
+	case syntax.OpAnyCharNotNL:
+		return generateFromClass(sb, []rune{' ', '~'})
+	case syntax.OpAnyChar:
+		return generateFromClass(sb, []rune{' ', '~', '\n', '\n'})
+	case syntax.OpCapture:
+		return generate(sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := generate(sb, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		i, err := randomInt(len(re.Sub))
+		if err != nil {
+			return err
+		}
+
+		return generate(sb, re.Sub[i])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := repeatBounds(re)
+		n, err := randomInt(max - min + 1)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < min+n; i++ {
+			if err := generate(sb, re.Sub[0]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported regular expression operator in %s", re.String())
+	}
+
+	return nil
+}
+
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, maxRepeat
+	case syntax.OpPlus:
+		return 1, maxRepeat
+	case syntax.OpQuest:
+		return 0, 1
+	default:
+		if re.Max < 0 {
+			return re.Min, re.Min + maxRepeat
+		}
+
+		return re.Min, re.Max
+	}
+}
+
+func generateFromClass(sb *strings.Builder, ranges []rune) error {
+	r, err := randomRune(ranges)
+	if err != nil {
+		return err
+	}
+
+	sb.WriteRune(r)
+	return nil
+}
+
+// randomRune returns a random rune from a list of rune ranges, as used by syntax.OpCharClass
+func randomRune(ranges []rune) (rune, error) {
+	total := 0
+	for i := 0; i < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+
+	if total == 0 {
+		return 0, fmt.Errorf("empty character class")
+	}
+
+	n, err := randomInt(total)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n), nil
+		}
+
+		n -= size
+	}
+
+	return 0, fmt.Errorf("invalid character class")
+}
+
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,54 @@
+package jmespath
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Random(t *testing.T) {
+	testCases := []string{
+		"[a-z0-9]{6}",
+		"^[a-f]{2,4}-[0-9]+$",
+		"(foo|bar)-\\d{3}",
+		"[[:alpha:]]{8}",
+		"prefix-.?[A-Z]*",
+	}
+
+	for i, pattern := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(fmt.Sprintf("random('%s')", pattern))
+			assert.NilError(t, err)
+
+			for j := 0; j < 10; j++ {
+				res, err := query.Search("")
+				assert.NilError(t, err)
+
+				result, ok := res.(string)
+				assert.Assert(t, ok)
+				assert.Assert(t, regexp.MustCompile("^(?:"+pattern+")$").MatchString(result), "%s does not match %s", result, pattern)
+			}
+		})
+	}
+
+	query, err := New("random('[a-')")
+	assert.NilError(t, err)
+	_, err = query.Search("")
+	assert.ErrorContains(t, err, "JMESPath function 'random'")
+}
+
+func Test_UUID(t *testing.T) {
+	query, err := New("uuid()")
+	assert.NilError(t, err)
+
+	res1, err := query.Search("")
+	assert.NilError(t, err)
+	res2, err := query.Search("")
+	assert.NilError(t, err)
+
+	uuidPattern := regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+	assert.Assert(t, uuidPattern.MatchString(res1.(string)))
+	assert.Assert(t, res1 != res2)
+}
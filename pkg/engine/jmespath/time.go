@@ -0,0 +1,163 @@
+package jmespath
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// parseTime parses an RFC 3339 timestamp argument
+func parseTime(f string, arguments []interface{}, index int) (time.Time, error) {
+	ts, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339, ts.String())
+	if err != nil {
+		return time.Time{}, fmt.Errorf(genericError, f, err.Error())
+	}
+
+	return t, nil
+}
+
+// parseDuration parses a Go duration argument, e.g. "1h30m"
+func parseDuration(f string, arguments []interface{}, index int) (time.Duration, error) {
+	d, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return 0, err
+	}
+
+	duration, err := time.ParseDuration(d.String())
+	if err != nil {
+		return 0, fmt.Errorf(genericError, f, err.Error())
+	}
+
+	return duration, nil
+}
+
+func jpTimeNow(arguments []interface{}) (interface{}, error) {
+	return time.Now().Format(time.RFC3339), nil
+}
+
+func jpTimeNowUtc(arguments []interface{}) (interface{}, error) {
+	return time.Now().UTC().Format(time.RFC3339), nil
+}
+
+func jpTimeAdd(arguments []interface{}) (interface{}, error) {
+	t, err := parseTime(timeAdd, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := parseDuration(timeAdd, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Add(d).Format(time.RFC3339), nil
+}
+
+func jpTimeParse(arguments []interface{}) (interface{}, error) {
+	layout, err := validateArg(timeParse, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := validateArg(timeParse, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse(layout.String(), ts.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, timeParse, err.Error())
+	}
+
+	return t.Format(time.RFC3339), nil
+}
+
+func jpTimeToCron(arguments []interface{}) (interface{}, error) {
+	t, err := parseTime(timeToCron, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t = t.UTC()
+	return fmt.Sprintf("%d %d %d %d %d", t.Minute(), t.Hour(), t.Day(), t.Month(), t.Weekday()), nil
+}
+
+func jpTimeBefore(arguments []interface{}) (interface{}, error) {
+	t1, err := parseTime(timeBefore, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t2, err := parseTime(timeBefore, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return t1.Before(t2), nil
+}
+
+func jpTimeAfter(arguments []interface{}) (interface{}, error) {
+	t1, err := parseTime(timeAfter, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t2, err := parseTime(timeAfter, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return t1.After(t2), nil
+}
+
+func jpTimeBetween(arguments []interface{}) (interface{}, error) {
+	t, err := parseTime(timeBetween, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := parseTime(timeBetween, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := parseTime(timeBetween, arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.After(start) && t.Before(end), nil
+}
+
+func jpTimeTruncate(arguments []interface{}) (interface{}, error) {
+	t, err := parseTime(timeTruncate, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := parseDuration(timeTruncate, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Truncate(d).Format(time.RFC3339), nil
+}
+
+func jpTimeDiff(arguments []interface{}) (interface{}, error) {
+	t1, err := parseTime(timeDiff, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t2, err := parseTime(timeDiff, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return t2.Sub(t1).String(), nil
+}
@@ -0,0 +1,146 @@
+package jmespath
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_TimeFunctions(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+	}{
+		{
+			test:           "time_add('2021-01-02T15:04:05Z', '3h30m')",
+			expectedResult: "2021-01-02T18:34:05Z",
+		},
+		{
+			test:           "time_add('2021-01-02T15:04:05Z', '-24h')",
+			expectedResult: "2021-01-01T15:04:05Z",
+		},
+		{
+			test:           "time_parse('Mon Jan _2 15:04:05 MST 2006', 'Sat Jan 02 15:04:05 UTC 2021')",
+			expectedResult: "2021-01-02T15:04:05Z",
+		},
+		{
+			test:           "time_parse('2006-01-02', '2021-01-02')",
+			expectedResult: "2021-01-02T00:00:00Z",
+		},
+		{
+			test:           "time_to_cron('2021-01-02T15:04:05Z')",
+			expectedResult: "4 15 2 1 6",
+		},
+		{
+			test:           "time_to_cron('2021-01-02T15:04:05+02:00')",
+			expectedResult: "4 13 2 1 6",
+		},
+		{
+			test:           "time_before('2021-01-02T15:04:05Z', '2021-01-03T15:04:05Z')",
+			expectedResult: true,
+		},
+		{
+			test:           "time_before('2021-01-03T15:04:05Z', '2021-01-02T15:04:05Z')",
+			expectedResult: false,
+		},
+		{
+			test:           "time_after('2021-01-03T15:04:05Z', '2021-01-02T15:04:05Z')",
+			expectedResult: true,
+		},
+		{
+			test:           "time_after('2021-01-02T15:04:05Z', '2021-01-02T15:04:05Z')",
+			expectedResult: false,
+		},
+		{
+			test:           "time_between('2021-01-02T15:04:05Z', '2021-01-01T00:00:00Z', '2021-01-03T00:00:00Z')",
+			expectedResult: true,
+		},
+		{
+			test:           "time_between('2021-01-04T15:04:05Z', '2021-01-01T00:00:00Z', '2021-01-03T00:00:00Z')",
+			expectedResult: false,
+		},
+		{
+			test:           "time_truncate('2021-01-02T15:04:05Z', '1h')",
+			expectedResult: "2021-01-02T15:00:00Z",
+		},
+		{
+			test:           "time_diff('2021-01-02T15:04:05Z', '2021-01-10T03:14:05Z')",
+			expectedResult: "180h10m0s",
+		},
+		{
+			test:           "time_diff('2021-01-10T03:14:05Z', '2021-01-02T15:04:05Z')",
+			expectedResult: "-180h10m0s",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_TimeNow(t *testing.T) {
+	for _, f := range []string{"time_now()", "time_now_utc()"} {
+		query, err := New(f)
+		assert.NilError(t, err)
+
+		res, err := query.Search("")
+		assert.NilError(t, err)
+
+		result, ok := res.(string)
+		assert.Assert(t, ok)
+
+		now, err := time.Parse(time.RFC3339, result)
+		assert.NilError(t, err)
+		assert.Assert(t, time.Since(now) < time.Minute)
+	}
+
+	query, err := New("time_before(time_now_utc(), time_add(time_now_utc(), '1h'))")
+	assert.NilError(t, err)
+
+	res, err := query.Search("")
+	assert.NilError(t, err)
+	assert.Equal(t, res, true)
+}
+
+func Test_TimeFunctionErrors(t *testing.T) {
+	testCases := []struct {
+		test          string
+		expectedError string
+	}{
+		{
+			test:          "time_add('2021-01-02', '1h')",
+			expectedError: "JMESPath function 'time_add': parsing time",
+		},
+		{
+			test:          "time_add('2021-01-02T15:04:05Z', '1 hour')",
+			expectedError: "JMESPath function 'time_add': time: unknown unit",
+		},
+		{
+			test:          "time_parse('2006-01-02', 'not a date')",
+			expectedError: "JMESPath function 'time_parse': parsing time",
+		},
+		{
+			test:          "time_between('2021-01-02T15:04:05Z', 'yesterday', '2021-01-03T00:00:00Z')",
+			expectedError: "JMESPath function 'time_between': parsing time",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			_, err = query.Search("")
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
@@ -1,15 +1,27 @@
 package engine
 
 import (
+	"bytes"
+	gocontext "context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/contextcache"
 	jmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // LoadContext - Fetches and adds external data to the Context.
@@ -42,6 +54,14 @@ func LoadContext(logger logr.Logger, contextEntries []kyvernov1.ContextEntry, ct
 				if err := loadVariable(logger, entry, ctx); err != nil {
 					return err
 				}
+			} else if entry.APICall != nil {
+				if err := loadMockAPIData(logger, entry, ctx); err != nil {
+					return err
+				}
+			} else if entry.ConfigMap != nil {
+				if err := loadMockConfigMap(logger, entry, ctx); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -62,6 +82,10 @@ func LoadContext(logger logr.Logger, contextEntries []kyvernov1.ContextEntry, ct
 				if err := loadAPIData(logger, entry, ctx); err != nil {
 					return err
 				}
+			} else if entry.Service != nil {
+				if err := loadServiceData(logger, entry, ctx, ruleName); err != nil {
+					return err
+				}
 			} else if entry.ImageRegistry != nil {
 				if err := loadImageData(logger, entry, ctx); err != nil {
 					return err
@@ -235,41 +259,124 @@ func fetchImageDataMap(ref string) (interface{}, error) {
 }
 
 func loadAPIData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
+	cache := contextcache.DefaultCache
+	if entry.APICall.Cache == nil {
+		cache = nil
+	}
+
+	var cacheKey string
+	var cacheResource *contextcache.Resource
+	if cache != nil {
+		var err error
+		cacheKey, cacheResource, err = apiCallCacheKey(logger, entry, ctx)
+		if err != nil {
+			return err
+		}
+
+		if data, ok := cache.Get(metrics.ContextEntryAPICall, cacheKey); ok {
+			return addContextEntry(entry, data, ctx)
+		}
+	}
+
 	jsonData, err := fetchAPIData(logger, entry, ctx)
 	if err != nil {
 		return err
 	}
 
-	if entry.APICall.JMESPath == "" {
-		err = ctx.JSONContext.AddContextEntry(entry.Name, jsonData)
-		if err != nil {
-			return fmt.Errorf("failed to add resource data to context: contextEntry: %v, error: %v", entry, err)
-		}
+	contextData, err := transformJSONData(logger, entry, entry.APICall.JMESPath, jsonData, ctx)
+	if err != nil {
+		return err
+	}
 
-		return nil
+	if cache != nil {
+		cache.Add(cacheKey, contextData, cacheTTL(entry.APICall.Cache), cacheResource)
 	}
 
-	path, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.APICall.JMESPath)
+	return addContextEntry(entry, contextData, ctx)
+}
+
+// apiCallCacheKey returns the cache key for an apiCall context entry, and the
+// resource used to invalidate the cached data.
+func apiCallCacheKey(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) (string, *contextcache.Resource, error) {
+	path, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.APICall.URLPath)
 	if err != nil {
-		return fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.JMESPath, err)
+		return "", nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.URLPath, err)
 	}
 
-	results, err := applyJMESPathJSON(path.(string), jsonData)
+	jmesPath, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.APICall.JMESPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.JMESPath, err)
+	}
+
+	method := apiCallMethod(entry.APICall)
+	data, err := buildAPICallData(logger, entry, ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := contextcache.APICallKey(string(method), path.(string), data, jmesPath.(string))
+
+	// POST requests do not read resources, so results are only invalidated by the TTL
+	if method != kyvernov1.MethodGet {
+		return key, nil, nil
+	}
+
+	return key, contextcache.ResourceFromURLPath(path.(string)), nil
+}
+
+func cacheTTL(cachePolicy *kyvernov1.CachePolicy) time.Duration {
+	if cachePolicy.TTL == nil {
+		return contextcache.DefaultTTL
+	}
+
+	return cachePolicy.TTL.Duration
+}
+
+func loadServiceData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext, ruleName string) error {
+	jsonData, err := fetchServiceData(logger, entry, ctx, ruleName)
 	if err != nil {
 		return err
 	}
 
-	contextData, err := json.Marshal(results)
+	contextData, err := transformJSONData(logger, entry, entry.Service.JMESPath, jsonData, ctx)
 	if err != nil {
-		return fmt.Errorf("failed to marshall data %v for context entry %v: %v", contextData, entry, err)
+		return err
 	}
 
-	err = ctx.JSONContext.AddContextEntry(entry.Name, contextData)
+	return addContextEntry(entry, contextData, ctx)
+}
+
+// transformJSONData applies the JMESPath expression of the context entry (if any)
+// to the JSON data, and returns the JSON encoded result.
+func transformJSONData(logger logr.Logger, entry kyvernov1.ContextEntry, jmesPath string, jsonData []byte, ctx *PolicyContext) ([]byte, error) {
+	if jmesPath == "" {
+		return jsonData, nil
+	}
+
+	path, err := variables.SubstituteAll(logger, ctx.JSONContext, jmesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, jmesPath, err)
+	}
+
+	results, err := applyJMESPathJSON(path.(string), jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	contextData, err := json.Marshal(results)
 	if err != nil {
-		return fmt.Errorf("failed to add JMESPath (%s) results to context, error: %v", entry.APICall.JMESPath, err)
+		return nil, fmt.Errorf("failed to marshall data %v for context entry %v: %v", contextData, entry, err)
+	}
+
+	logger.V(4).Info("applied JMESPath to context entry", "name", entry.Name, "len", len(contextData))
+	return contextData, nil
+}
+
+func addContextEntry(entry kyvernov1.ContextEntry, data []byte, ctx *PolicyContext) error {
+	if err := ctx.JSONContext.AddContextEntry(entry.Name, data); err != nil {
+		return fmt.Errorf("failed to add resource data to context: contextEntry: %v, error: %v", entry, err)
 	}
 
-	logger.V(4).Info("added APICall context entry", "len", len(contextData))
 	return nil
 }
 
@@ -303,7 +410,12 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 
 	pathStr := path.(string)
 
-	jsonData, err := getResource(ctx, pathStr)
+	data, err := buildAPICallData(log, entry, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := getResource(ctx, pathStr, apiCallMethod(entry.APICall), data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource with raw url\n: %s: %v", pathStr, err)
 	}
@@ -311,8 +423,258 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 	return jsonData, nil
 }
 
-func getResource(ctx *PolicyContext, p string) ([]byte, error) {
-	return ctx.Client.RawAbsPath(p)
+func apiCallMethod(apiCall *kyvernov1.APICall) kyvernov1.Method {
+	if apiCall.Method == "" {
+		return kyvernov1.MethodGet
+	}
+
+	return apiCall.Method
+}
+
+// buildAPICallData substitutes variables in the request data of the apiCall
+// and returns the JSON encoded request body, or nil if there is no data.
+func buildAPICallData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	if len(entry.APICall.Data) == 0 {
+		return nil, nil
+	}
+
+	data := map[string]interface{}{}
+	for _, d := range entry.APICall.Data {
+		value, err := variables.DocumentToUntyped(d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %s in context entry %s: %v", d.Key, entry.Name, err)
+		}
+
+		value, err = variables.SubstituteAll(log, ctx.JSONContext, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in context entry %s data %s: %v", entry.Name, d.Key, err)
+		}
+
+		data[d.Key] = value
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data for context entry %s: %v", entry.Name, err)
+	}
+
+	return body, nil
+}
+
+func getResource(ctx *PolicyContext, p string, method kyvernov1.Method, data []byte) ([]byte, error) {
+	var dataReader io.Reader
+	if data != nil {
+		dataReader = bytes.NewReader(data)
+	}
+
+	return ctx.Client.RawAbsPath(p, string(method), dataReader)
+}
+
+// defaultServiceCallTimeout is the timeout used for service calls that do not specify one
+const defaultServiceCallTimeout = 10 * time.Second
+
+func fetchServiceData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext, ruleName string) ([]byte, error) {
+	if entry.Service == nil {
+		return nil, fmt.Errorf("missing service in context entry %s %v", entry.Name, entry.Service)
+	}
+
+	url, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.Service.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.Service.URL, err)
+	}
+
+	urlStr, ok := url.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid service URL %v in context entry %s, URL must be a string", url, entry.Name)
+	}
+
+	method := entry.Service.Method
+	if method == "" {
+		method = kyvernov1.MethodGet
+	}
+
+	body, err := buildServiceCallBody(logger, entry, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientKey := fmt.Sprintf("%s/%s/%s/%s", ctx.Policy.GetNamespace(), ctx.Policy.GetName(), ruleName, entry.Name)
+	client, err := buildServiceCallClient(clientKey, entry.Service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client for context entry %s: %v", entry.Name, err)
+	}
+
+	timeout := defaultServiceCallTimeout
+	if entry.Service.Timeout != nil {
+		timeout = entry.Service.Timeout.Duration
+	}
+
+	reqCtx, cancel := gocontext.WithTimeout(gocontext.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, string(method), urlStr, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request for context entry %s: %v", entry.Name, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call service %s for context entry %s: %v", urlStr, entry.Name, err)
+	}
+
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from service %s for context entry %s: %v", urlStr, entry.Name, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("service %s returned HTTP %d for context entry %s: %s", urlStr, resp.StatusCode, entry.Name, string(data))
+	}
+
+	logger.V(4).Info("called service", "url", urlStr, "method", method, "status", resp.StatusCode)
+	return data, nil
+}
+
+// buildServiceCallBody evaluates the body JMESPath expression of the service call
+// against the rule context and returns the JSON encoded result.
+func buildServiceCallBody(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) (io.Reader, error) {
+	if entry.Service.Body == "" {
+		return nil, nil
+	}
+
+	path, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.Service.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.Service.Body, err)
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid body %v in context entry %s, body must be a JMESPath expression", path, entry.Name)
+	}
+
+	data, err := ctx.JSONContext.Query(pathStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply JMESPath %s for the body of context entry %s: %v", entry.Service.Body, entry.Name, err)
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the body of context entry %s: %v", entry.Name, err)
+	}
+
+	return bytes.NewReader(body), nil
+}
+
+// serviceCallClient is the HTTP client of a service call and the CA bundle it trusts
+type serviceCallClient struct {
+	caBundle string
+	client   *http.Client
+}
+
+// serviceCallClients caches the HTTP clients of the service calls by policy, rule and context entry,
+// so that the rule evaluations share the connection pool of a transport instead of opening new connections
+var (
+	serviceCallClients     = map[string]*serviceCallClient{}
+	serviceCallClientsLock sync.Mutex
+)
+
+// buildServiceCallClient returns the HTTP client trusting the CA bundle of the service call, the client
+// cached for the key is replaced when the CA bundle changes. The timeout of the call is set on the request context.
+func buildServiceCallClient(key string, service *kyvernov1.ServiceCall) (*http.Client, error) {
+	serviceCallClientsLock.Lock()
+	defer serviceCallClientsLock.Unlock()
+
+	cached, ok := serviceCallClients[key]
+	if ok && cached.caBundle == service.CABundle {
+		return cached.client, nil
+	}
+
+	if ok {
+		cached.client.CloseIdleConnections()
+		delete(serviceCallClients, key)
+	}
+
+	if service.CABundle == "" {
+		return http.DefaultClient, nil
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM([]byte(service.CABundle)) {
+		return nil, fmt.Errorf("failed to parse PEM CA bundle")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    caCertPool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	client := &http.Client{Transport: transport}
+	serviceCallClients[key] = &serviceCallClient{caBundle: service.CABundle, client: client}
+	return client, nil
+}
+
+// loadMockAPIData loads the canned response of an apiCall context entry in mock mode.
+// Entries without a canned response are left to the values provided by the user.
+func loadMockAPIData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
+	if len(store.APICalls) == 0 {
+		return nil
+	}
+
+	path, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.APICall.URLPath)
+	if err != nil {
+		return fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.URLPath, err)
+	}
+
+	apiCall := store.GetAPICall(path.(string))
+	if apiCall == nil {
+		logger.V(3).Info("no mocked response for API call", "name", entry.Name, "urlPath", path)
+		return nil
+	}
+
+	jsonData, err := json.Marshal(apiCall.Response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mocked response for context entry %s: %v", entry.Name, err)
+	}
+
+	contextData, err := transformJSONData(logger, entry, entry.APICall.JMESPath, jsonData, ctx)
+	if err != nil {
+		return err
+	}
+
+	return addContextEntry(entry, contextData, ctx)
+}
+
+// loadMockConfigMap loads the mocked config map of a configMap context entry in mock mode.
+// Entries without a mocked config map are left to the values provided by the user.
+func loadMockConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
+	if len(store.Resources) == 0 {
+		return nil
+	}
+
+	namespace, name, err := configMapNamespacedName(logger, entry, ctx)
+	if err != nil {
+		return err
+	}
+
+	obj := store.GetResource("v1", "ConfigMap", namespace, name)
+	if obj == nil {
+		logger.V(3).Info("no mocked config map", "name", entry.Name, "configMap", namespace+"/"+name)
+		return nil
+	}
+
+	data, err := configMapData(obj)
+	if err != nil {
+		return err
+	}
+
+	return addContextEntry(entry, data, ctx)
 }
 
 func loadConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
@@ -330,27 +692,62 @@ func loadConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Policy
 }
 
 func fetchConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
-	contextData := make(map[string]interface{})
+	namespace, name, err := configMapNamespacedName(logger, entry, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := contextcache.DefaultCache
+	if entry.ConfigMap.Cache == nil {
+		cache = nil
+	}
+
+	cacheKey := contextcache.ConfigMapKey(namespace, name)
+	if cache != nil {
+		if data, ok := cache.Get(metrics.ContextEntryConfigMap, cacheKey); ok {
+			return data, nil
+		}
+	}
+
+	obj, err := ctx.Client.GetResource("v1", "ConfigMap", namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+	}
+
+	data, err := configMapData(obj)
+	if err != nil {
+		return nil, err
+	}
 
+	if cache != nil {
+		cache.Add(cacheKey, data, cacheTTL(entry.ConfigMap.Cache), contextcache.ConfigMapResource(namespace, name))
+	}
+
+	return data, nil
+}
+
+// configMapNamespacedName substitutes variables in the namespace and name of the configMap context entry
+func configMapNamespacedName(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) (string, string, error) {
 	name, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.ConfigMap.Name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to substitute variables in context %s configMap.name %s: %v", entry.Name, entry.ConfigMap.Name, err)
+		return "", "", fmt.Errorf("failed to substitute variables in context %s configMap.name %s: %v", entry.Name, entry.ConfigMap.Name, err)
 	}
 
 	namespace, err := variables.SubstituteAll(logger, ctx.JSONContext, entry.ConfigMap.Namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to substitute variables in context %s configMap.namespace %s: %v", entry.Name, entry.ConfigMap.Namespace, err)
+		return "", "", fmt.Errorf("failed to substitute variables in context %s configMap.namespace %s: %v", entry.Name, entry.ConfigMap.Namespace, err)
 	}
 
 	if namespace == "" {
 		namespace = "default"
 	}
 
-	obj, err := ctx.Client.GetResource("v1", "ConfigMap", namespace.(string), name.(string))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
-	}
+	return namespace.(string), name.(string), nil
+}
 
+// configMapData returns the JSON encoded data and metadata of the config map
+func configMapData(obj *unstructured.Unstructured) ([]byte, error) {
+	contextData := make(map[string]interface{})
 	unstructuredObj := obj.DeepCopy().Object
 
 	// extract configmap data
@@ -358,7 +755,7 @@ func fetchConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 	contextData["metadata"] = unstructuredObj["metadata"]
 	data, err := json.Marshal(contextData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to unmarshal configmap %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
 	}
 
 	return data, nil
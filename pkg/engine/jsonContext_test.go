@@ -0,0 +1,349 @@
+package engine
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/contextcache"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var serviceCallPolicy = `{
+	"apiVersion": "kyverno.io/v1",
+	"kind": "ClusterPolicy",
+	"metadata": {
+		"name": "service-call"
+	},
+	"spec": {
+		"rules": []
+	}
+}`
+
+var serviceCallResource = `{
+	"apiVersion": "v1",
+	"kind": "Pod",
+	"metadata": {
+		"name": "test",
+		"namespace": "default",
+		"labels": {
+			"owner": "team-a"
+		}
+	},
+	"spec": {
+		"containers": [
+			{
+				"name": "test",
+				"image": "nginx:latest"
+			}
+		]
+	}
+}`
+
+func newServiceCallServer(t *testing.T) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		if r.Method == http.MethodPost {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, json.Unmarshal(body, &request))
+			assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		}
+
+		switch r.URL.Path {
+		case "/owners/team-a":
+			_, _ = w.Write([]byte(`{"owner": {"name": "team-a", "approved": true}}`))
+		case "/owners":
+			response, _ := json.Marshal(map[string]interface{}{"method": r.Method, "request": request})
+			_, _ = w.Write(response)
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_ServiceCall(t *testing.T) {
+	server := newServiceCallServer(t)
+	defer server.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	entry := kyverno.ContextEntry{
+		Name: "owner",
+		Service: &kyverno.ServiceCall{
+			URL:      server.URL + "/owners/{{ request.object.metadata.labels.owner }}",
+			CABundle: caBundle,
+			JMESPath: "owner.approved",
+		},
+	}
+
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	err := loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.NilError(t, err)
+
+	approved, err := ctx.JSONContext.Query("owner")
+	assert.NilError(t, err)
+	assert.Equal(t, approved, true)
+}
+
+func Test_ServiceCallPost(t *testing.T) {
+	server := newServiceCallServer(t)
+	defer server.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	entry := kyverno.ContextEntry{
+		Name: "result",
+		Service: &kyverno.ServiceCall{
+			URL:      server.URL + "/owners",
+			Method:   kyverno.MethodPost,
+			Body:     "{namespace: request.object.metadata.namespace, owner: request.object.metadata.labels.owner}",
+			CABundle: caBundle,
+		},
+	}
+
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	err := loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.NilError(t, err)
+
+	method, err := ctx.JSONContext.Query("result.method")
+	assert.NilError(t, err)
+	assert.Equal(t, method, "POST")
+
+	owner, err := ctx.JSONContext.Query("result.request.owner")
+	assert.NilError(t, err)
+	assert.Equal(t, owner, "team-a")
+
+	namespace, err := ctx.JSONContext.Query("result.request.namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "default")
+}
+
+func Test_ServiceCallErrors(t *testing.T) {
+	server := newServiceCallServer(t)
+	defer server.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+
+	// the server certificate is not trusted without the CA bundle
+	entry := kyverno.ContextEntry{Name: "result", Service: &kyverno.ServiceCall{URL: server.URL + "/owners/team-a"}}
+	err := loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.ErrorContains(t, err, "failed to call service")
+
+	entry = kyverno.ContextEntry{Name: "result", Service: &kyverno.ServiceCall{URL: server.URL + "/unknown", CABundle: caBundle}}
+	err = loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.ErrorContains(t, err, "returned HTTP 404")
+
+	entry = kyverno.ContextEntry{Name: "result", Service: &kyverno.ServiceCall{
+		URL:      server.URL + "/slow",
+		CABundle: caBundle,
+		Timeout:  &metav1.Duration{Duration: 50 * time.Millisecond},
+	}}
+	err = loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.ErrorContains(t, err, "failed to call service")
+
+	// the body must resolve to a JMESPath expression
+	entry = kyverno.ContextEntry{Name: "result", Service: &kyverno.ServiceCall{
+		URL:      server.URL + "/owners",
+		Method:   kyverno.MethodPost,
+		Body:     "{{ request.object.metadata.labels }}",
+		CABundle: caBundle,
+	}}
+	err = loadServiceData(logr.Discard(), entry, ctx, "check-owner")
+	assert.ErrorContains(t, err, "body must be a JMESPath expression")
+}
+
+func Test_ServiceCallClientCache(t *testing.T) {
+	server := newServiceCallServer(t)
+	defer server.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	client, err := buildServiceCallClient("policy/rule/owner", &kyverno.ServiceCall{CABundle: caBundle, Timeout: &metav1.Duration{Duration: time.Second}})
+	assert.NilError(t, err)
+
+	// the client is shared by the evaluations of the same context entry
+	other, err := buildServiceCallClient("policy/rule/owner", &kyverno.ServiceCall{CABundle: caBundle})
+	assert.NilError(t, err)
+	assert.Assert(t, client == other)
+
+	// the client is replaced when the CA bundle of the context entry changes
+	rotated, err := buildServiceCallClient("policy/rule/owner", &kyverno.ServiceCall{CABundle: caBundle + caBundle})
+	assert.NilError(t, err)
+	assert.Assert(t, client != rotated)
+	assert.Equal(t, serviceCallClients["policy/rule/owner"].client, rotated)
+
+	defaultClient, err := buildServiceCallClient("policy/rule/owner", &kyverno.ServiceCall{})
+	assert.NilError(t, err)
+	assert.Equal(t, defaultClient, http.DefaultClient)
+	_, ok := serviceCallClients["policy/rule/owner"]
+	assert.Assert(t, !ok)
+
+	_, err = buildServiceCallClient("policy/rule/invalid", &kyverno.ServiceCall{CABundle: "invalid"})
+	assert.ErrorContains(t, err, "failed to parse PEM CA bundle")
+}
+
+// rawAPIClient records the raw API calls and returns a fixed response
+type rawAPIClient struct {
+	dclient.Interface
+	calls    int
+	path     string
+	method   string
+	data     []byte
+	response []byte
+}
+
+func (c *rawAPIClient) RawAbsPath(path string, method string, dataReader io.Reader) ([]byte, error) {
+	c.calls++
+	c.path, c.method, c.data = path, method, nil
+	if dataReader != nil {
+		data, err := ioutil.ReadAll(dataReader)
+		if err != nil {
+			return nil, err
+		}
+
+		c.data = data
+	}
+
+	return c.response, nil
+}
+
+func Test_APICallPost(t *testing.T) {
+	client := &rawAPIClient{response: []byte(`{"kind": "SubjectAccessReview", "status": {"allowed": true}}`)}
+	entry := kyverno.ContextEntry{
+		Name: "sar",
+		APICall: &kyverno.APICall{
+			URLPath: "/apis/authorization.k8s.io/v1/subjectaccessreviews",
+			Method:  kyverno.MethodPost,
+			Data: []kyverno.RequestData{
+				{Key: "kind", Value: &apiextv1.JSON{Raw: []byte(`"SubjectAccessReview"`)}},
+				{Key: "apiVersion", Value: &apiextv1.JSON{Raw: []byte(`"authorization.k8s.io/v1"`)}},
+				{Key: "spec", Value: &apiextv1.JSON{Raw: []byte(`{"resourceAttributes": {"namespace": "{{ request.object.metadata.namespace }}", "verb": "delete", "resource": "pods"}, "user": "{{ request.object.metadata.labels.owner }}"}`)}},
+			},
+			JMESPath: "status.allowed",
+		},
+	}
+
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	ctx.Client = client
+	err := loadAPIData(logr.Discard(), entry, ctx)
+	assert.NilError(t, err)
+
+	assert.Equal(t, client.method, "POST")
+	assert.Equal(t, client.path, "/apis/authorization.k8s.io/v1/subjectaccessreviews")
+
+	var request map[string]interface{}
+	assert.NilError(t, json.Unmarshal(client.data, &request))
+	assert.DeepEqual(t, request, map[string]interface{}{
+		"kind":       "SubjectAccessReview",
+		"apiVersion": "authorization.k8s.io/v1",
+		"spec": map[string]interface{}{
+			"resourceAttributes": map[string]interface{}{"namespace": "default", "verb": "delete", "resource": "pods"},
+			"user":               "team-a",
+		},
+	})
+
+	allowed, err := ctx.JSONContext.Query("sar")
+	assert.NilError(t, err)
+	assert.Equal(t, allowed, true)
+}
+
+func Test_APICallCache(t *testing.T) {
+	contextcache.DefaultCache = contextcache.NewCache(nil, nil, nil)
+	defer func() { contextcache.DefaultCache = nil }()
+
+	client := &rawAPIClient{response: []byte(`{"items": [{}, {}, {}]}`)}
+	entry := kyverno.ContextEntry{
+		Name: "count",
+		APICall: &kyverno.APICall{
+			URLPath:  "/apis/apps/v1/namespaces/{{ request.object.metadata.namespace }}/deployments",
+			JMESPath: "items | length(@)",
+			Cache:    &kyverno.CachePolicy{},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+		ctx.Client = client
+		assert.NilError(t, loadAPIData(logr.Discard(), entry, ctx))
+
+		count, err := ctx.JSONContext.Query("count")
+		assert.NilError(t, err)
+		assert.Equal(t, count, 3.0)
+	}
+	assert.Equal(t, client.calls, 1)
+	assert.Equal(t, client.method, "GET")
+	assert.Equal(t, client.path, "/apis/apps/v1/namespaces/default/deployments")
+
+	// entries without a cache policy are not cached
+	entry.APICall.Cache = nil
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	ctx.Client = client
+	assert.NilError(t, loadAPIData(logr.Discard(), entry, ctx))
+	assert.Equal(t, client.calls, 2)
+}
+
+func Test_MockAPICall(t *testing.T) {
+	store.SetAPICalls([]store.APICall{
+		{URLPath: "/api/v1/namespaces/default/pods", Response: map[string]interface{}{"items": []interface{}{map[string]interface{}{}, map[string]interface{}{}}}},
+	})
+	defer store.SetAPICalls(nil)
+
+	entry := kyverno.ContextEntry{
+		Name: "count",
+		APICall: &kyverno.APICall{
+			URLPath:  "/api/v1/namespaces/{{ request.object.metadata.namespace }}/pods",
+			JMESPath: "items | length(@)",
+		},
+	}
+
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	assert.NilError(t, loadMockAPIData(logr.Discard(), entry, ctx))
+	count, err := ctx.JSONContext.Query("count")
+	assert.NilError(t, err)
+	assert.Equal(t, count, 2.0)
+
+	// entries without a mocked response are not loaded
+	entry.Name = "missing"
+	entry.APICall.URLPath = "/api/v1/namespaces/other/pods"
+	assert.NilError(t, loadMockAPIData(logr.Discard(), entry, ctx))
+	_, err = ctx.JSONContext.Query("missing.items")
+	assert.ErrorContains(t, err, "Unknown key")
+}
+
+func Test_MockConfigMap(t *testing.T) {
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "namespace": "default"},
+		"data":       map[string]interface{}{"owner": "team-a"},
+	}}
+	store.SetResources([]*unstructured.Unstructured{configMap})
+	defer store.SetResources(nil)
+
+	entry := kyverno.ContextEntry{
+		Name:      "settings",
+		ConfigMap: &kyverno.ConfigMapReference{Name: "settings"},
+	}
+
+	ctx := buildContext(t, serviceCallPolicy, serviceCallResource, "")
+	assert.NilError(t, loadMockConfigMap(logr.Discard(), entry, ctx))
+	owner, err := ctx.JSONContext.Query("settings.data.owner")
+	assert.NilError(t, err)
+	assert.Equal(t, owner, "team-a")
+}
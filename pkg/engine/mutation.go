@@ -52,7 +52,7 @@ func Mutate(policyContext *PolicyContext) (resp *response.EngineResponse) {
 			excludeResource = policyContext.ExcludeGroupRole
 		}
 
-		if err = MatchesResourceDescription(matchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels, policyContext.Policy.GetNamespace()); err != nil {
+		if err = MatchesResourceDescription(matchedResource, rule, policyContext.AdmissionInfo, excludeResource, policyContext.NamespaceLabels, policyContext.Policy.GetNamespace(), requestOperation(policyContext.JSONContext)); err != nil {
 			logger.V(4).Info("rule not matched", "reason", err.Error())
 			skippedRules = append(skippedRules, rule.Name)
 			continue
@@ -168,7 +168,8 @@ func mutateForEach(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructur
 	var applyCount int
 	allPatches := make([][]byte, 0)
 
-	for _, foreach := range foreachList {
+	var branches []string
+	for i, foreach := range foreachList {
 		if err := LoadContext(logger, rule.Context, ctx, rule.Name); err != nil {
 			logger.Error(err, "failed to load context")
 			return ruleError(rule, response.Mutation, "failed to load context", err), resource
@@ -192,11 +193,12 @@ func mutateForEach(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructur
 		mutateResp := mutateElements(rule.Name, foreach, ctx, elements, patchedResource, logger)
 		if mutateResp.Status == response.RuleStatusError {
 			logger.Error(err, "failed to mutate elements")
-			return buildRuleResponse(rule, mutateResp, nil), resource
+			return recordBranches(ctx, buildRuleResponse(rule, mutateResp, nil), foreachBranch(i)), resource
 		}
 
 		if mutateResp.Status != response.RuleStatusSkip {
 			applyCount++
+			branches = append(branches, foreachBranch(i))
 			if len(mutateResp.Patches) > 0 {
 				patchedResource = mutateResp.PatchedResource
 				allPatches = append(allPatches, mutateResp.Patches...)
@@ -210,7 +212,7 @@ func mutateForEach(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructur
 
 	r := ruleResponse(*rule, response.Mutation, fmt.Sprintf("%d elements processed", applyCount), response.RuleStatusPass, &patchedResource)
 	r.Patches = allPatches
-	return r, patchedResource
+	return recordBranches(ctx, r, branches...), patchedResource
 }
 
 func mutateElements(name string, foreach kyvernov1.ForEachMutation, ctx *PolicyContext, elements []interface{}, resource unstructured.Unstructured, logger logr.Logger) *mutate.Response {
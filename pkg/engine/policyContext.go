@@ -5,6 +5,7 @@ import (
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -41,8 +42,16 @@ type PolicyContext struct {
 
 	// AdmissionOperation represents if the caller is from the webhook server
 	AdmissionOperation bool
+
+	// BranchRecorder is called with the branches of the rules that produced their status, if set.
+	// It is not copied to the contexts of the foreach elements.
+	BranchRecorder BranchRecorder
 }
 
+// BranchRecorder records the anyPattern alternatives (anyPattern[i]) or foreach entries (foreach[i])
+// of a rule that produced the status of the rule response
+type BranchRecorder func(policy kyvernov1.PolicyInterface, rule string, branches []string, status response.RuleStatus)
+
 func (pc *PolicyContext) Copy() *PolicyContext {
 	return &PolicyContext{
 		Policy:              pc.Policy,
@@ -905,7 +905,7 @@ func TestMatchesResourceDescription(t *testing.T) {
 		resource, _ := utils.ConvertToUnstructured(tc.Resource)
 
 		for _, rule := range autogen.ComputeRules(&policy) {
-			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, []string{}, nil, "")
+			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, []string{}, nil, "", "")
 			if err != nil {
 				if !tc.areErrorsExpected {
 					t.Errorf("Testcase %d Unexpected error: %v\nmsg: %s", i+1, err, tc.Description)
@@ -973,12 +973,33 @@ func TestResourceDescriptionMatch_MultipleKind(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 
 }
 
+func TestResourceDescriptionMatch_Operations(t *testing.T) {
+	resource, err := utils.ConvertToUnstructured([]byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx"}}`))
+	assert.NilError(t, err)
+
+	rule := v1.Rule{MatchResources: v1.MatchResources{Any: v1.ResourceFilters{{
+		ResourceDescription: v1.ResourceDescription{
+			Kinds:      []string{"Pod"},
+			Operations: []v1.AdmissionOperation{v1.Create, v1.Update},
+		},
+	}}}}
+
+	assert.NilError(t, MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", v1.Update))
+	assert.Assert(t, MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", v1.Delete) != nil)
+	// outside of an admission request, the resource is matched as created
+	assert.NilError(t, MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""))
+
+	rule.ExcludeResources = v1.MatchResources{ResourceDescription: v1.ResourceDescription{Operations: []v1.AdmissionOperation{v1.Update}}}
+	assert.Assert(t, MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", v1.Update) != nil)
+	assert.NilError(t, MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", v1.Create))
+}
+
 // Match resource name
 func TestResourceDescriptionMatch_Name(t *testing.T) {
 	rawResource := []byte(`{
@@ -1034,7 +1055,7 @@ func TestResourceDescriptionMatch_Name(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -1094,7 +1115,7 @@ func TestResourceDescriptionMatch_Name_Regex(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -1162,7 +1183,7 @@ func TestResourceDescriptionMatch_Label_Expression_NotMatch(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -1231,7 +1252,7 @@ func TestResourceDescriptionMatch_Label_Expression_Match(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err != nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -1311,7 +1332,7 @@ func TestResourceDescriptionExclude_Label_Expression_Match(t *testing.T) {
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription},
 		ExcludeResources: v1.MatchResources{ResourceDescription: resourceDescriptionExclude}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, ""); err == nil {
+	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, []string{}, nil, "", ""); err == nil {
 		t.Errorf("Testcase has failed due to the following:\n Function has returned no error, even though it was supposed to fail")
 	}
 }
@@ -289,7 +289,8 @@ func (v *validator) validateForEach() *response.RuleResponse {
 		return nil
 	}
 
-	for _, foreach := range foreachList {
+	var branches []string
+	for i, foreach := range foreachList {
 		elements, err := evaluateList(foreach.List, v.ctx.JSONContext)
 		if err != nil {
 			v.log.V(2).Info("failed to evaluate list", "list", foreach.List, "error", err.Error())
@@ -298,7 +299,11 @@ func (v *validator) validateForEach() *response.RuleResponse {
 
 		resp, count := v.validateElements(foreach, elements, foreach.ElementScope)
 		if resp.Status != response.RuleStatusPass {
-			return resp
+			return recordBranches(v.ctx, resp, foreachBranch(i))
+		}
+
+		if count > 0 {
+			branches = append(branches, foreachBranch(i))
 		}
 
 		applyCount += count
@@ -308,7 +313,23 @@ func (v *validator) validateForEach() *response.RuleResponse {
 		return ruleResponse(*v.rule, response.Validation, "rule skipped", response.RuleStatusSkip, nil)
 	}
 
-	return ruleResponse(*v.rule, response.Validation, "rule passed", response.RuleStatusPass, nil)
+	return recordBranches(v.ctx, ruleResponse(*v.rule, response.Validation, "rule passed", response.RuleStatusPass, nil), branches...)
+}
+
+// recordBranches reports the branches that produced the status of the rule response to the branch recorder of the policy context
+func recordBranches(ctx *PolicyContext, resp *response.RuleResponse, branches ...string) *response.RuleResponse {
+	if ctx.BranchRecorder != nil && len(branches) > 0 {
+		ctx.BranchRecorder(ctx.Policy, resp.Name, branches, resp.Status)
+	}
+	return resp
+}
+
+func foreachBranch(index int) string {
+	return fmt.Sprintf("foreach[%d]", index)
+}
+
+func anyPatternBranch(index int) string {
+	return fmt.Sprintf("anyPattern[%d]", index)
 }
 
 func (v *validator) validateElements(foreach kyvernov1.ForEachValidation, elements []interface{}, elementScope *bool) (*response.RuleResponse, int) {
@@ -573,13 +594,14 @@ func isEmptyUnstructured(u *unstructured.Unstructured) bool {
 
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func matches(logger logr.Logger, rule *kyvernov1.Rule, ctx *PolicyContext) bool {
-	err := MatchesResourceDescription(ctx.NewResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "")
+	operation := requestOperation(ctx.JSONContext)
+	err := MatchesResourceDescription(ctx.NewResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "", operation)
 	if err == nil {
 		return true
 	}
 
 	if !reflect.DeepEqual(ctx.OldResource, unstructured.Unstructured{}) {
-		err := MatchesResourceDescription(ctx.OldResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "")
+		err := MatchesResourceDescription(ctx.OldResource, *rule, ctx.AdmissionInfo, ctx.ExcludeGroupRole, ctx.NamespaceLabels, "", operation)
 		if err == nil {
 			return true
 		}
@@ -650,7 +672,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *respon
 			err := validate.MatchPattern(v.log, resource.Object, pattern)
 			if err == nil {
 				msg := fmt.Sprintf("validation rule '%s' anyPattern[%d] passed.", v.rule.Name, idx)
-				return ruleResponse(*v.rule, response.Validation, msg, response.RuleStatusPass, nil)
+				return recordBranches(v.ctx, ruleResponse(*v.rule, response.Validation, msg, response.RuleStatusPass, nil), anyPatternBranch(idx))
 			}
 
 			if pe, ok := err.(*validate.PatternError); ok {
@@ -674,7 +696,11 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *respon
 
 			v.log.V(4).Info(fmt.Sprintf("Validation rule '%s' failed. %s", v.rule.Name, errorStr))
 			msg := buildAnyPatternErrorMessage(v.rule, errorStr)
-			return ruleResponse(*v.rule, response.Validation, msg, response.RuleStatusFail, nil)
+			var branches []string
+			for idx := range anyPatterns {
+				branches = append(branches, anyPatternBranch(idx))
+			}
+			return recordBranches(v.ctx, ruleResponse(*v.rule, response.Validation, msg, response.RuleStatusFail, nil), branches...)
 		}
 	}
 
@@ -315,6 +315,79 @@ func TestValidate_Fail_anyPattern(t *testing.T) {
 	}
 }
 
+func TestValidate_BranchRecorder(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-branches"},
+		"spec": {
+			"rules": [
+				{
+					"name": "any-pattern",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"anyPattern": [
+							{"metadata": {"labels": {"team": "?*"}}},
+							{"metadata": {"labels": {"app": "?*"}}}
+						]
+					}
+				},
+				{
+					"name": "foreach",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"foreach": [
+							{"list": "request.object.spec.initContainers", "pattern": {"image": "!*:latest"}},
+							{"list": "request.object.spec.containers", "pattern": {"image": "!*:latest"}}
+						]
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+
+	type branchRecord struct {
+		Branches []string
+		Status   response.RuleStatus
+	}
+	validate := func(rawResource []byte) map[string]branchRecord {
+		resourceUnstructured, err := utils.ConvertToUnstructured(rawResource)
+		assert.NilError(t, err)
+
+		ctx := context.NewContext()
+		assert.NilError(t, context.AddResource(ctx, rawResource))
+
+		records := map[string]branchRecord{}
+		recorder := func(p kyverno.PolicyInterface, rule string, branches []string, status response.RuleStatus) {
+			assert.Equal(t, p.GetName(), "check-branches")
+			records[rule] = branchRecord{Branches: branches, Status: status}
+		}
+		Validate(&PolicyContext{Policy: &policy, NewResource: *resourceUnstructured, JSONContext: ctx, BranchRecorder: recorder})
+		return records
+	}
+
+	records := validate([]byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "myapp-pod", "labels": {"app": "myapp"}},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx:1.21"}]}
+	}`))
+	assert.DeepEqual(t, records["any-pattern"], branchRecord{Branches: []string{"anyPattern[1]"}, Status: response.RuleStatusPass})
+	assert.DeepEqual(t, records["foreach"], branchRecord{Branches: []string{"foreach[1]"}, Status: response.RuleStatusPass})
+
+	records = validate([]byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "myapp-pod"},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx:latest"}]}
+	}`))
+	assert.DeepEqual(t, records["any-pattern"], branchRecord{Branches: []string{"anyPattern[0]", "anyPattern[1]"}, Status: response.RuleStatusFail})
+	assert.DeepEqual(t, records["foreach"], branchRecord{Branches: []string{"foreach[1]"}, Status: response.RuleStatusFail})
+}
+
 func TestValidate_host_network_port(t *testing.T) {
 	rawPolicy := []byte(`
 	{
@@ -277,6 +277,60 @@ func TestEvaluate(t *testing.T) {
 		{kyverno.Condition{RawKey: kyverno.ToJSON("1.5.0"), Operator: kyverno.ConditionOperators["LessThanOrEquals"], RawValue: kyverno.ToJSON("1.5.5")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON("1.5.5"), Operator: kyverno.ConditionOperators["LessThanOrEquals"], RawValue: kyverno.ToJSON("1.5.0")}, false},
 
+		// Versions
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("v1.20.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3-gke.100"), Operator: kyverno.ConditionOperators["GreaterThanOrEquals"], RawValue: kyverno.ToJSON("1.24")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.19.16"), Operator: kyverno.ConditionOperators["GreaterThanOrEquals"], RawValue: kyverno.ToJSON("v1.20")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3"), Operator: kyverno.ConditionOperators["LessThan"], RawValue: kyverno.ToJSON("1.25.0")}, true},
+
+		// Range
+		{kyverno.Condition{RawKey: kyverno.ToJSON(5), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1-10")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(10), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1-10")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(11), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1-10")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(-5), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("-10--1")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(2.5), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1.5-3")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("500m"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("100m-2")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("3"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("100m-2")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1Gi"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("512Mi-2Gi")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("2h"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1h-24h")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("30m"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1h-24h")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("v1.20.0-v1.25.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.26.0"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("v1.20.0-v1.25.0")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3-gke.100"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1.24.0-1.25")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.3"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON(">=1.20.0 <1.25.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.25.0"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON(">=1.20.0 <1.25.0")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("foo"), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("1-10")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(5), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON("foo")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(5), Operator: kyverno.ConditionOperators["Range"], RawValue: kyverno.ToJSON([]interface{}{1, 10})}, false},
+
+		// Not Range
+		{kyverno.Condition{RawKey: kyverno.ToJSON(5), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON("1-10")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(11), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON("1-10")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("30m"), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON("1h-24h")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.25.0"), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON(">=1.20.0 <1.25.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.24.0"), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON(">=1.20.0 <1.25.0")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("foo"), Operator: kyverno.ConditionOperators["NotRange"], RawValue: kyverno.ToJSON("1-10")}, false},
+
+		// Regex Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx-12"), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON("^nginx-[0-9]+$")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx-latest"), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON("^nginx-[0-9]+$")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("ghcr.io/kyverno/kyverno"), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON([]interface{}{"^docker\\.io/", "^ghcr\\.io/"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(10), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON("^[0-9]{2}$")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"nginx-1"}), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON("^nginx-[0-9]+$")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx"), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON("^nginx-([")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx"), Operator: kyverno.ConditionOperators["RegexMatches"], RawValue: kyverno.ToJSON(10)}, false},
+
+		// Any Regex Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"docker.io/nginx", "ghcr.io/kyverno/kyverno"}), Operator: kyverno.ConditionOperators["AnyRegexMatches"], RawValue: kyverno.ToJSON("^ghcr\\.io/")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"docker.io/nginx", "quay.io/coreos/etcd"}), Operator: kyverno.ConditionOperators["AnyRegexMatches"], RawValue: kyverno.ToJSON([]interface{}{"^ghcr\\.io/", "^gcr\\.io/"})}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("ghcr.io/kyverno/kyverno"), Operator: kyverno.ConditionOperators["AnyRegexMatches"], RawValue: kyverno.ToJSON("^ghcr\\.io/")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{}), Operator: kyverno.ConditionOperators["AnyRegexMatches"], RawValue: kyverno.ToJSON("^ghcr\\.io/")}, false},
+
+		// All Regex Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"ghcr.io/kyverno/kyverno", "gcr.io/distroless/static"}), Operator: kyverno.ConditionOperators["AllRegexMatches"], RawValue: kyverno.ToJSON([]interface{}{"^ghcr\\.io/", "^gcr\\.io/"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"ghcr.io/kyverno/kyverno", "docker.io/nginx"}), Operator: kyverno.ConditionOperators["AllRegexMatches"], RawValue: kyverno.ToJSON("^ghcr\\.io/")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"ghcr.io/kyverno/kyverno", map[string]interface{}{"image": "ghcr.io/nginx"}}), Operator: kyverno.ConditionOperators["AllRegexMatches"], RawValue: kyverno.ToJSON("^ghcr\\.io/")}, false},
+
 		// In
 		{kyverno.Condition{RawKey: kyverno.ToJSON(1), Operator: kyverno.ConditionOperators["In"], RawValue: kyverno.ToJSON([]interface{}{1, 2, 3})}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON(1.5), Operator: kyverno.ConditionOperators["In"], RawValue: kyverno.ToJSON([]interface{}{1, 1.5, 2, 3})}, true},
@@ -138,7 +138,7 @@ func (noh NumericOperatorHandler) validateValueWithFloatPattern(key float64, val
 func (noh NumericOperatorHandler) validateValueWithVersionPattern(key semver.Version, value interface{}) bool {
 	switch typedValue := value.(type) {
 	case string:
-		versionValue, err := semver.Parse(typedValue)
+		versionValue, err := semver.ParseTolerant(typedValue)
 		if err != nil {
 			noh.log.Error(fmt.Errorf("parse error: "), "Failed to parse value type doesn't match key type")
 			return false
@@ -171,8 +171,8 @@ func (noh NumericOperatorHandler) validateValueWithStringPattern(key string, val
 	if err == nil {
 		return noh.validateValueWithIntPattern(int64key, value)
 	}
-	// attempt to extract version from string
-	versionKey, err := semver.Parse(key)
+	// attempt to extract version from string, allowing a "v" prefix and missing minor or patch versions
+	versionKey, err := semver.ParseTolerant(key)
 	if err == nil {
 		return noh.validateValueWithVersionPattern(versionKey, value)
 	}
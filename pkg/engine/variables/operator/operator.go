@@ -62,6 +62,17 @@ func CreateOperatorHandler(log logr.Logger, ctx context.EvalInterface, op kyvern
 		strings.ToLower(string(kyvernov1.ConditionOperators["LessThan"])):
 		return NewNumericOperatorHandler(log, ctx, op)
 
+	case strings.ToLower(string(kyvernov1.ConditionOperators["Range"])):
+		return NewRangeHandler(log, ctx)
+
+	case strings.ToLower(string(kyvernov1.ConditionOperators["NotRange"])):
+		return NewNotRangeHandler(log, ctx)
+
+	case strings.ToLower(string(kyvernov1.ConditionOperators["RegexMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AnyRegexMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AllRegexMatches"])):
+		return NewRegexMatchHandler(log, ctx, op)
+
 	case strings.ToLower(string(kyvernov1.ConditionOperators["DurationGreaterThanOrEquals"])),
 		strings.ToLower(string(kyvernov1.ConditionOperators["DurationGreaterThan"])),
 		strings.ToLower(string(kyvernov1.ConditionOperators["DurationLessThanOrEquals"])),
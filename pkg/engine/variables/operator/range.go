@@ -0,0 +1,189 @@
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewRangeHandler returns handler to manage Range operations
+func NewRangeHandler(log logr.Logger, ctx context.EvalInterface) OperatorHandler {
+	return RangeHandler{
+		ctx: ctx,
+		log: log,
+	}
+}
+
+// RangeHandler provides implementation to handle Range operator
+type RangeHandler struct {
+	ctx context.EvalInterface
+	log logr.Logger
+}
+
+// Evaluate evaluates expression with Range Operator
+func (r RangeHandler) Evaluate(key, value interface{}) bool {
+	valid, inRange := evaluateRange(r.log, r.ctx, key, value)
+	return valid && inRange
+}
+
+// NewNotRangeHandler returns handler to manage NotRange operations
+func NewNotRangeHandler(log logr.Logger, ctx context.EvalInterface) OperatorHandler {
+	return NotRangeHandler{
+		ctx: ctx,
+		log: log,
+	}
+}
+
+// NotRangeHandler provides implementation to handle NotRange operator
+type NotRangeHandler struct {
+	ctx context.EvalInterface
+	log logr.Logger
+}
+
+// Evaluate evaluates expression with NotRange Operator
+func (nr NotRangeHandler) Evaluate(key, value interface{}) bool {
+	valid, inRange := evaluateRange(nr.log, nr.ctx, key, value)
+	return valid && !inRange
+}
+
+// evaluateRange checks if the key is within the inclusive range given by the value.
+// The value is either formatted as "min-max", where min and max are numbers, quantities,
+// durations or versions, or is a semver range expression such as ">=1.20.0 <1.25.0".
+// The first return value is false if the key and value cannot be compared.
+func evaluateRange(log logr.Logger, ctx context.EvalInterface, key, value interface{}) (bool, bool) {
+	typedValue, ok := value.(string)
+	if !ok {
+		log.V(2).Info("Expected type string", "value", value, "type", fmt.Sprintf("%T", value))
+		return false, false
+	}
+
+	switch typedKey := key.(type) {
+	case int, int64, float64:
+	case string:
+		if !isRangeBound(typedKey) {
+			log.V(2).Info("Key is not a number, quantity, duration or version", "key", key)
+			return false, false
+		}
+	default:
+		log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false, false
+	}
+
+	if min, max, ok := splitRange(typedValue); ok {
+		gte := NewNumericOperatorHandler(log, ctx, kyvernov1.ConditionOperators["GreaterThanOrEquals"]).Evaluate(key, min)
+		lte := NewNumericOperatorHandler(log, ctx, kyvernov1.ConditionOperators["LessThanOrEquals"]).Evaluate(key, max)
+		return true, gte && lte
+	}
+
+	versionRange, err := semver.ParseRange(typedValue)
+	if err != nil {
+		log.V(2).Info("Value is not a valid range", "value", value)
+		return false, false
+	}
+
+	typedKey, ok := key.(string)
+	if !ok {
+		log.V(2).Info("Expected a version key for a semver range", "key", key, "type", fmt.Sprintf("%T", key))
+		return false, false
+	}
+
+	version, err := semver.ParseTolerant(typedKey)
+	if err != nil {
+		log.V(2).Info("Key is not a valid version", "key", key)
+		return false, false
+	}
+
+	return true, versionRange(version)
+}
+
+// splitRange splits a "min-max" range into its bounds. As '-' can also be part of a bound
+// (negative numbers or semver pre-releases), every separator is tried until both bounds are valid.
+func splitRange(value string) (string, string, bool) {
+	for i := 1; i < len(value); i++ {
+		if value[i] != '-' {
+			continue
+		}
+
+		min, max := strings.TrimSpace(value[:i]), strings.TrimSpace(value[i+1:])
+		if isRangeBound(min) && isRangeBound(max) {
+			return min, max, true
+		}
+	}
+
+	return "", "", false
+}
+
+// isRangeBound checks if the value is a number, quantity, duration or version
+func isRangeBound(value string) bool {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return true
+	}
+
+	if _, err := time.ParseDuration(value); err == nil {
+		return true
+	}
+
+	if _, err := resource.ParseQuantity(value); err == nil {
+		return true
+	}
+
+	_, err := semver.ParseTolerant(value)
+	return err == nil
+}
+
+// the following functions are unreachable because Evaluate handles all key types
+// still the following functions are just created to make the range handlers implement OperatorHandler interface
+func (r RangeHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	return false
+}
+
+func (r RangeHandler) validateValueWithBoolPattern(key bool, value interface{}) bool {
+	return false
+}
+
+func (r RangeHandler) validateValueWithIntPattern(key int64, value interface{}) bool {
+	return false
+}
+
+func (r RangeHandler) validateValueWithFloatPattern(key float64, value interface{}) bool {
+	return false
+}
+
+func (r RangeHandler) validateValueWithMapPattern(key map[string]interface{}, value interface{}) bool {
+	return false
+}
+
+func (r RangeHandler) validateValueWithSlicePattern(key []interface{}, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithBoolPattern(key bool, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithIntPattern(key int64, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithFloatPattern(key float64, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithMapPattern(key map[string]interface{}, value interface{}) bool {
+	return false
+}
+
+func (nr NotRangeHandler) validateValueWithSlicePattern(key []interface{}, value interface{}) bool {
+	return false
+}
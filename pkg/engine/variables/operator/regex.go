@@ -0,0 +1,179 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// NewRegexMatchHandler returns handler to manage the provided regex operations (RegexMatches, AnyRegexMatches, AllRegexMatches)
+func NewRegexMatchHandler(log logr.Logger, ctx context.EvalInterface, op kyvernov1.ConditionOperator) OperatorHandler {
+	return RegexMatchHandler{
+		ctx:       ctx,
+		log:       log,
+		condition: op,
+	}
+}
+
+// RegexMatchHandler provides implementation to handle regex operations associated with policies
+type RegexMatchHandler struct {
+	ctx       context.EvalInterface
+	log       logr.Logger
+	condition kyvernov1.ConditionOperator
+}
+
+// IsRegexOperator checks if the operator uses regular expressions as values
+func IsRegexOperator(op kyvernov1.ConditionOperator) bool {
+	switch strings.ToLower(string(op)) {
+	case strings.ToLower(string(kyvernov1.ConditionOperators["RegexMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AnyRegexMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AllRegexMatches"])):
+		return true
+	default:
+		return false
+	}
+}
+
+// RegexPatterns returns the regular expressions of a condition using a regex operator.
+// Patterns containing variables are skipped as they are only known at evaluation time.
+func RegexPatterns(condition kyvernov1.Condition) ([]string, error) {
+	if !IsRegexOperator(condition.Operator) {
+		return nil, nil
+	}
+
+	patterns, err := toPatterns(condition.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "{{") {
+			result = append(result, pattern)
+		}
+	}
+
+	return result, nil
+}
+
+// Evaluate evaluates expression with the regex operators
+func (r RegexMatchHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string, int, int32, int64, float32, float64, bool:
+		return r.validateValueWithStringPattern(fmt.Sprint(typedKey), value)
+	case []interface{}:
+		if strings.EqualFold(string(r.condition), string(kyvernov1.ConditionOperators["RegexMatches"])) {
+			r.log.V(2).Info("Expected a single key, use AnyRegexMatches or AllRegexMatches for a list of keys", "key", key)
+			return false
+		}
+		return r.validateValueWithSlicePattern(typedKey, value)
+	default:
+		r.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+func (r RegexMatchHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	patterns, err := toPatterns(value)
+	if err != nil {
+		r.log.V(2).Info(err.Error(), "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+
+	matches, err := matchesAny(key, patterns)
+	if err != nil {
+		r.log.Error(err, "failed to compile regular expression", "value", value)
+		return false
+	}
+
+	return matches
+}
+
+func (r RegexMatchHandler) validateValueWithSlicePattern(key []interface{}, value interface{}) bool {
+	patterns, err := toPatterns(value)
+	if err != nil {
+		r.log.V(2).Info(err.Error(), "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+
+	anyMatch := strings.EqualFold(string(r.condition), string(kyvernov1.ConditionOperators["AnyRegexMatches"]))
+	for _, k := range key {
+		switch k.(type) {
+		case map[string]interface{}, []interface{}:
+			r.log.V(2).Info("Unsupported type", "value", k, "type", fmt.Sprintf("%T", k))
+			return false
+		}
+
+		matches, err := matchesAny(fmt.Sprint(k), patterns)
+		if err != nil {
+			r.log.Error(err, "failed to compile regular expression", "value", value)
+			return false
+		}
+
+		if anyMatch && matches {
+			return true
+		}
+
+		if !anyMatch && !matches {
+			return false
+		}
+	}
+
+	return !anyMatch
+}
+
+// toPatterns converts the condition value, a string or a list of strings, to regular expressions
+func toPatterns(value interface{}) ([]string, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return []string{typedValue}, nil
+	case []interface{}:
+		var patterns []string
+		for _, v := range typedValue {
+			pattern, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected regular expressions of type string, found %T", v)
+			}
+			patterns = append(patterns, pattern)
+		}
+		return patterns, nil
+	default:
+		return nil, fmt.Errorf("expected a regular expression or a list of regular expressions, found %T", value)
+	}
+}
+
+func matchesAny(key string, patterns []string) (bool, error) {
+	for _, pattern := range patterns {
+		re, err := DefaultRegexCache.Compile(pattern)
+		if err != nil {
+			return false, err
+		}
+
+		if re.MatchString(key) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// the following functions are unreachable because Evaluate converts all scalar keys to strings
+// still the following functions are just created to make RegexMatchHandler struct implement OperatorHandler interface
+func (r RegexMatchHandler) validateValueWithBoolPattern(key bool, value interface{}) bool {
+	return false
+}
+
+func (r RegexMatchHandler) validateValueWithIntPattern(key int64, value interface{}) bool {
+	return false
+}
+
+func (r RegexMatchHandler) validateValueWithFloatPattern(key float64, value interface{}) bool {
+	return false
+}
+
+func (r RegexMatchHandler) validateValueWithMapPattern(key map[string]interface{}, value interface{}) bool {
+	return false
+}
@@ -0,0 +1,92 @@
+package operator
+
+import (
+	"regexp"
+	"sync"
+)
+
+// DefaultRegexCache is the cache used by the regex operators
+var DefaultRegexCache = NewRegexCache()
+
+// RegexCache stores the compiled regular expressions of the regex operators.
+// Patterns are registered per policy so that they are compiled once, when the policy is loaded,
+// and released when the policy is removed. Patterns not registered by a policy, for example
+// patterns built from variables, are compiled on each evaluation.
+type RegexCache interface {
+	// Set compiles and stores the patterns of a policy, replacing its previous patterns
+	Set(policyKey string, patterns []string)
+	// Unset removes the patterns of a policy
+	Unset(policyKey string)
+	// Compile returns the compiled pattern
+	Compile(pattern string) (*regexp.Regexp, error)
+}
+
+type regexEntry struct {
+	re   *regexp.Regexp
+	refs int
+}
+
+type regexCache struct {
+	lock     sync.RWMutex
+	policies map[string][]string
+	entries  map[string]*regexEntry
+}
+
+// NewRegexCache creates a new RegexCache
+func NewRegexCache() RegexCache {
+	return &regexCache{
+		policies: map[string][]string{},
+		entries:  map[string]*regexEntry{},
+	}
+}
+
+func (c *regexCache) Set(policyKey string, patterns []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.unset(policyKey)
+	var registered []string
+	for _, pattern := range patterns {
+		if entry, ok := c.entries[pattern]; ok {
+			entry.refs++
+		} else {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				// invalid patterns are rejected by policy validation, evaluation reports the error
+				continue
+			}
+			c.entries[pattern] = &regexEntry{re: re, refs: 1}
+		}
+		registered = append(registered, pattern)
+	}
+	if len(registered) > 0 {
+		c.policies[policyKey] = registered
+	}
+}
+
+func (c *regexCache) Unset(policyKey string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.unset(policyKey)
+}
+
+func (c *regexCache) unset(policyKey string) {
+	for _, pattern := range c.policies[policyKey] {
+		if entry, ok := c.entries[pattern]; ok {
+			entry.refs--
+			if entry.refs == 0 {
+				delete(c.entries, pattern)
+			}
+		}
+	}
+	delete(c.policies, policyKey)
+}
+
+func (c *regexCache) Compile(pattern string) (*regexp.Regexp, error) {
+	c.lock.RLock()
+	entry, ok := c.entries[pattern]
+	c.lock.RUnlock()
+	if ok {
+		return entry.re, nil
+	}
+	return regexp.Compile(pattern)
+}
@@ -0,0 +1,32 @@
+package images
+
+// ImageVerifier verifies image signatures and fetches signed attestations.
+// Each supported signature format (e.g. Cosign, Notary v2) provides an implementation.
+type ImageVerifier interface {
+	// VerifySignature verifies that the image has the expected signatures
+	VerifySignature(opts Options) (*Response, error)
+
+	// FetchAttestations retrieves signed attestations and decodes them into in-toto statements
+	// https://github.com/in-toto/attestation/blob/main/spec/README.md#statement
+	FetchAttestations(opts Options) (*Response, error)
+}
+
+type Options struct {
+	ImageRef             string
+	FetchAttestations    bool
+	Key                  string
+	Cert                 string
+	CertChain            string
+	Roots                string
+	Subject              string
+	Issuer               string
+	AdditionalExtensions map[string]string
+	Annotations          map[string]string
+	Repository           string
+	RekorURL             string
+}
+
+type Response struct {
+	Digest     string
+	Statements []map[string]interface{}
+}
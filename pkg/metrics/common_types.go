@@ -78,3 +78,17 @@ const (
 	KyvernoClient      ClientType = "kyverno"
 	PolicyReportClient ClientType = "policyreport"
 )
+
+type ContextEntryType string
+
+const (
+	ContextEntryAPICall   ContextEntryType = "apiCall"
+	ContextEntryConfigMap ContextEntryType = "configMap"
+)
+
+type ContextCacheResult string
+
+const (
+	ContextCacheHit  ContextCacheResult = "hit"
+	ContextCacheMiss ContextCacheResult = "miss"
+)
@@ -40,6 +40,7 @@ type MetricsConfig struct {
 	admissionRequestsMetric       syncint64.Counter
 	admissionReviewDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	contextCacheQueriesMetric     syncint64.Counter
 
 	// config
 	Config *kconfig.MetricsConfigData
@@ -54,6 +55,7 @@ type MetricsConfigManager interface {
 	RecordPolicyExecutionDuration(policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, resourceKind string, resourceNamespace string, resourceRequestOperation ResourceRequestOperation, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, generalRuleLatencyType string, ruleExecutionLatency float64)
 	RecordAdmissionReviewDuration(resourceKind string, resourceNamespace string, resourceRequestOperation string, admissionRequestLatency float64)
 	RecordClientQueries(clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordContextCacheQueries(contextEntryType ContextEntryType, cacheResult ContextCacheResult)
 }
 
 func initializeMetrics(m *MetricsConfig) (*MetricsConfig, error) {
@@ -103,6 +105,12 @@ func initializeMetrics(m *MetricsConfig) (*MetricsConfig, error) {
 		return nil, err
 	}
 
+	m.contextCacheQueriesMetric, err = meter.SyncInt64().Counter("kyverno_context_cache_queries_total", instrument.WithDescription("can be used to track the hit and miss rates of the cache used for apiCall and configMap context entries"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_context_cache_queries_total")
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -356,3 +364,14 @@ func (m *MetricsConfig) RecordClientQueries(clientQueryOperation ClientQueryOper
 
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordContextCacheQueries(contextEntryType ContextEntryType, cacheResult ContextCacheResult) {
+	ctx := context.Background()
+
+	commonLabels := []attribute.KeyValue{
+		attribute.String("context_entry_type", string(contextEntryType)),
+		attribute.String("cache_result", string(cacheResult)),
+	}
+
+	m.contextCacheQueriesMetric.Add(ctx, 1, commonLabels...)
+}
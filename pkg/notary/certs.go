@@ -0,0 +1,49 @@
+package notary
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// certPool holds the trusted certificates used to verify signing certificates
+type certPool struct {
+	roots *x509.CertPool
+}
+
+func loadCertPool(cert, certChain string) (*certPool, error) {
+	if cert == "" && certChain == "" {
+		return nil, fmt.Errorf("a certificate or certificate chain is required")
+	}
+
+	roots := x509.NewCertPool()
+	for _, pem := range []string{cert, certChain} {
+		if pem != "" && !roots.AppendCertsFromPEM([]byte(pem)) {
+			return nil, fmt.Errorf("failed to load certificates")
+		}
+	}
+
+	return &certPool{roots: roots}, nil
+}
+
+// verify checks that the signing certificate (the first entry of the chain) is
+// valid for code signing and chains to one of the trusted certificates.
+func (p *certPool) verify(chain []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+
+	if _, err := chain[0].Verify(opts); err != nil {
+		return errors.Wrap(err, "failed to verify certificate chain")
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package notary
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	mediaTypePayload = "application/vnd.cncf.notary.payload.v1+json"
+
+	headerSigningScheme   = "io.cncf.notary.signingScheme"
+	headerSigningTime     = "io.cncf.notary.signingTime"
+	headerExpiry          = "io.cncf.notary.expiry"
+	headerAuthSigningTime = "io.cncf.notary.authenticSigningTime"
+
+	signingSchemeX509 = "notary.x509"
+)
+
+// jwsEnvelope is the JWS JSON serialization of a Notary v2 signature.
+// See: https://github.com/notaryproject/notaryproject/blob/main/specs/signature-envelope-jws.md
+type jwsEnvelope struct {
+	Payload   string            `json:"payload"`
+	Protected string            `json:"protected"`
+	Header    unprotectedHeader `json:"header"`
+	Signature string            `json:"signature"`
+}
+
+type unprotectedHeader struct {
+	CertChain [][]byte `json:"x5c"`
+}
+
+type protectedHeader struct {
+	Algorithm     string     `json:"alg"`
+	ContentType   string     `json:"cty"`
+	Critical      []string   `json:"crit"`
+	SigningScheme string     `json:"io.cncf.notary.signingScheme"`
+	SigningTime   *time.Time `json:"io.cncf.notary.signingTime,omitempty"`
+	Expiry        *time.Time `json:"io.cncf.notary.expiry,omitempty"`
+}
+
+type payload struct {
+	TargetArtifact targetArtifact `json:"targetArtifact"`
+}
+
+// targetArtifact is the descriptor of the signed artifact
+type targetArtifact struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// verifyJWSEnvelope verifies the signature of the envelope with the signing certificate
+// from the envelope, and verifies that the certificate chains to one of the trusted roots.
+func verifyJWSEnvelope(raw []byte, roots *certPool) (*payload, error) {
+	var envelope jwsEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JWS envelope")
+	}
+
+	header, err := decodeProtectedHeader(envelope.Protected)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Expiry != nil && time.Now().After(*header.Expiry) {
+		return nil, fmt.Errorf("signature expired at %s", header.Expiry.String())
+	}
+
+	certs, err := parseCertChain(envelope.Header.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := roots.verify(certs); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature")
+	}
+
+	signingInput := envelope.Protected + "." + envelope.Payload
+	if err := verifyJWS(header.Algorithm, certs[0], []byte(signingInput), signature); err != nil {
+		return nil, err
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+
+	var p payload
+	if err := json.Unmarshal(rawPayload, &p); err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+
+	return &p, nil
+}
+
+func decodeProtectedHeader(protected string) (*protectedHeader, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode protected header")
+	}
+
+	var header protectedHeader
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, errors.Wrap(err, "failed to decode protected header")
+	}
+
+	if header.ContentType != mediaTypePayload {
+		return nil, fmt.Errorf("unsupported payload content type %s", header.ContentType)
+	}
+
+	if header.SigningScheme != signingSchemeX509 {
+		return nil, fmt.Errorf("unsupported signing scheme %s", header.SigningScheme)
+	}
+
+	for _, c := range header.Critical {
+		switch c {
+		case headerSigningScheme, headerSigningTime, headerExpiry, headerAuthSigningTime:
+		default:
+			return nil, fmt.Errorf("unsupported critical header %s", c)
+		}
+	}
+
+	return &header, nil
+}
+
+func parseCertChain(chain [][]byte) ([]*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("certificate chain is missing in the signature envelope")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(chain))
+	for _, der := range chain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse certificate chain")
+		}
+
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+func verifyJWS(alg string, cert *x509.Certificate, signingInput, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature algorithm %s", alg)
+	}
+
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "PS") {
+			return fmt.Errorf("signature algorithm %s does not match RSA certificate", alg)
+		}
+
+		if err := rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("signature algorithm %s does not match ECDSA certificate", alg)
+		}
+
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature length %d", len(signature))
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package notary
+
+import "sigs.k8s.io/controller-runtime/pkg/log"
+
+var logger = log.Log.WithName("notary")
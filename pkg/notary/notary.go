@@ -0,0 +1,153 @@
+package notary
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+const (
+	// ArtifactTypeNotation is the artifact type of Notary v2 signatures
+	ArtifactTypeNotation = "application/vnd.cncf.notary.signature"
+
+	// MediaTypeJWSEnvelope is the media type of a JWS signature envelope
+	MediaTypeJWSEnvelope = "application/jose+json"
+)
+
+type notaryVerifier struct{}
+
+// NewVerifier returns an ImageVerifier for Notary v2 signatures. Signatures are
+// fetched as OCI referrers of the image and verified against the trusted certificates.
+func NewVerifier() images.ImageVerifier {
+	return &notaryVerifier{}
+}
+
+// signatureManifest is the OCI manifest of a Notary v2 signature artifact
+type signatureManifest struct {
+	Layers []registryclient.Referrer `json:"layers"`
+	Blobs  []registryclient.Referrer `json:"blobs"`
+}
+
+// VerifySignature verifies that the image has at least one Notary v2 signature
+// signed by a certificate chaining to the trusted certificates.
+func (v *notaryVerifier) VerifySignature(opts images.Options) (*images.Response, error) {
+	ref, err := name.ParseReference(opts.ImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+
+	roots, err := loadCertPool(opts.Cert, opts.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := registryclient.DefaultClient.FetchImageDescriptor(opts.ImageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := ref.Context()
+	if opts.Repository != "" {
+		repo, err = name.NewRepository(opts.Repository)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse signature repository %s", opts.Repository)
+		}
+	}
+
+	target := targetArtifact{
+		MediaType: string(desc.MediaType),
+		Digest:    desc.Digest.String(),
+		Size:      desc.Size,
+	}
+
+	referrers, err := registryclient.DefaultClient.FetchReferrers(repo.Digest(target.Digest).String(), ArtifactTypeNotation)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(referrers) == 0 {
+		return nil, fmt.Errorf("no signatures found for %s", opts.ImageRef)
+	}
+
+	logger.V(4).Info("fetched signatures", "image", opts.ImageRef, "count", len(referrers))
+
+	var errs []error
+	for _, r := range referrers {
+		err := verifySignatureArtifact(repo, r, target, roots, opts.Annotations)
+		if err == nil {
+			logger.V(3).Info("verified image", "image", opts.ImageRef, "signature", r.Digest)
+			return &images.Response{Digest: target.Digest}, nil
+		}
+
+		errs = append(errs, errors.Wrapf(err, "signature %s", r.Digest))
+	}
+
+	logger.Info("image verification failed", "image", opts.ImageRef, "errors", errs)
+	return nil, multierr.Combine(errs...)
+}
+
+// FetchAttestations is not supported for Notary v2 signatures
+func (v *notaryVerifier) FetchAttestations(opts images.Options) (*images.Response, error) {
+	return nil, fmt.Errorf("attestations are not supported for Notary v2 signatures")
+}
+
+func verifySignatureArtifact(repo name.Repository, r registryclient.Referrer, target targetArtifact, roots *certPool, annotations map[string]string) error {
+	desc, err := registryclient.DefaultClient.FetchImageDescriptor(repo.Digest(r.Digest).String())
+	if err != nil {
+		return err
+	}
+
+	var manifest signatureManifest
+	if err := json.Unmarshal(desc.Manifest, &manifest); err != nil {
+		return errors.Wrap(err, "failed to decode signature manifest")
+	}
+
+	// OCI image manifests store the envelope as a layer, ORAS artifact manifests as a blob
+	blobs := append(manifest.Layers, manifest.Blobs...)
+	if len(blobs) != 1 {
+		return fmt.Errorf("expected a single signature envelope, found %d", len(blobs))
+	}
+
+	if blobs[0].MediaType != MediaTypeJWSEnvelope {
+		return fmt.Errorf("unsupported signature envelope media type %s", blobs[0].MediaType)
+	}
+
+	raw, err := registryclient.DefaultClient.FetchBlob(repo.Digest(blobs[0].Digest).String())
+	if err != nil {
+		return err
+	}
+
+	payload, err := verifyJWSEnvelope(raw, roots)
+	if err != nil {
+		return err
+	}
+
+	return checkTargetArtifact(payload.TargetArtifact, target, annotations)
+}
+
+func checkTargetArtifact(signed, target targetArtifact, annotations map[string]string) error {
+	if signed.Digest != target.Digest {
+		return fmt.Errorf("digest mismatch: expected %s, received %s", target.Digest, signed.Digest)
+	}
+
+	if signed.Size != target.Size {
+		return fmt.Errorf("size mismatch: expected %d, received %d", target.Size, signed.Size)
+	}
+
+	if signed.MediaType != target.MediaType {
+		return fmt.Errorf("media type mismatch: expected %s, received %s", target.MediaType, signed.MediaType)
+	}
+
+	for k, v := range annotations {
+		if signed.Annotations[k] != v {
+			return fmt.Errorf("annotations mismatch: %s does not match expected value %s for key %s", signed.Annotations[k], v, k)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package notary
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyverno/kyverno/pkg/images"
+	"gotest.tools/assert"
+)
+
+type rawManifest struct {
+	mediaType types.MediaType
+	data      []byte
+}
+
+func (m *rawManifest) RawManifest() ([]byte, error) {
+	return m.data, nil
+}
+
+func (m *rawManifest) MediaType() (types.MediaType, error) {
+	return m.mediaType, nil
+}
+
+type testSigner struct {
+	caPEM []byte
+	cert  *x509.Certificate
+	key   *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.NilError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	assert.NilError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test-signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+
+	return &testSigner{
+		caPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		cert:  cert,
+		key:   key,
+	}
+}
+
+func (s *testSigner) sign(t *testing.T, target targetArtifact) []byte {
+	protected, err := json.Marshal(map[string]interface{}{
+		"alg":                         "ES256",
+		"cty":                         mediaTypePayload,
+		"crit":                        []string{headerSigningScheme},
+		headerSigningScheme:           signingSchemeX509,
+		headerSigningTime:             time.Now().Format(time.RFC3339),
+		"io.cncf.notary.signingAgent": "test",
+	})
+	assert.NilError(t, err)
+
+	p, err := json.Marshal(payload{TargetArtifact: target})
+	assert.NilError(t, err)
+
+	envelope := jwsEnvelope{
+		Protected: base64.RawURLEncoding.EncodeToString(protected),
+		Payload:   base64.RawURLEncoding.EncodeToString(p),
+		Header:    unprotectedHeader{CertChain: [][]byte{s.cert.Raw}},
+	}
+
+	digest := sha256.Sum256([]byte(envelope.Protected + "." + envelope.Payload))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	assert.NilError(t, err)
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+	envelope.Signature = base64.RawURLEncoding.EncodeToString(signature)
+
+	raw, err := json.Marshal(envelope)
+	assert.NilError(t, err)
+	return raw
+}
+
+// pushSignature pushes the signature envelope as a Notary v2 signature artifact
+// and links it to the image with the referrers tag schema.
+func pushSignature(t *testing.T, repo name.Repository, subject v1.Descriptor, envelope []byte) {
+	layer := static.NewLayer(envelope, MediaTypeJWSEnvelope)
+	assert.NilError(t, remote.WriteLayer(repo, layer))
+	layerDigest, err := layer.Digest()
+	assert.NilError(t, err)
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     types.OCIManifestSchema1,
+		"config": map[string]interface{}{
+			"mediaType": ArtifactTypeNotation,
+			"digest":    "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+			"size":      2,
+		},
+		"layers": []interface{}{
+			map[string]interface{}{
+				"mediaType": MediaTypeJWSEnvelope,
+				"digest":    layerDigest.String(),
+				"size":      len(envelope),
+			},
+		},
+		"subject": subject,
+	})
+	assert.NilError(t, err)
+
+	sig := &rawManifest{mediaType: types.OCIManifestSchema1, data: manifest}
+	assert.NilError(t, remote.Tag(repo.Tag("signature"), sig))
+
+	index, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     types.OCIImageIndex,
+		"manifests": []interface{}{
+			map[string]interface{}{
+				"mediaType":    types.OCIManifestSchema1,
+				"artifactType": ArtifactTypeNotation,
+				"digest":       fmt.Sprintf("sha256:%x", sha256.Sum256(manifest)),
+				"size":         len(manifest),
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	referrersTag := repo.Tag(strings.Replace(subject.Digest.String(), ":", "-", 1))
+	assert.NilError(t, remote.Tag(referrersTag, &rawManifest{mediaType: types.OCIImageIndex, data: index}))
+}
+
+func pushImage(t *testing.T, ref name.Reference) v1.Descriptor {
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, img))
+
+	digest, err := img.Digest()
+	assert.NilError(t, err)
+	size, err := img.Size()
+	assert.NilError(t, err)
+	mediaType, err := img.MediaType()
+	assert.NilError(t, err)
+
+	return v1.Descriptor{MediaType: mediaType, Digest: digest, Size: size}
+}
+
+func Test_VerifySignature(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	imageRef := host + "/test/signed:v1"
+	ref, err := name.ParseReference(imageRef)
+	assert.NilError(t, err)
+
+	desc := pushImage(t, ref)
+	signer := newTestSigner(t)
+	target := targetArtifact{
+		MediaType:   string(desc.MediaType),
+		Digest:      desc.Digest.String(),
+		Size:        desc.Size,
+		Annotations: map[string]string{"env": "prod"},
+	}
+	pushSignature(t, ref.Context(), desc, signer.sign(t, target))
+
+	verifier := NewVerifier()
+	resp, err := verifier.VerifySignature(images.Options{ImageRef: imageRef, CertChain: string(signer.caPEM)})
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Digest, desc.Digest.String())
+
+	opts := images.Options{ImageRef: imageRef, CertChain: string(signer.caPEM), Annotations: map[string]string{"env": "prod"}}
+	_, err = verifier.VerifySignature(opts)
+	assert.NilError(t, err)
+
+	opts.Annotations = map[string]string{"env": "dev"}
+	_, err = verifier.VerifySignature(opts)
+	assert.ErrorContains(t, err, "annotations mismatch")
+
+	otherSigner := newTestSigner(t)
+	_, err = verifier.VerifySignature(images.Options{ImageRef: imageRef, CertChain: string(otherSigner.caPEM)})
+	assert.ErrorContains(t, err, "failed to verify certificate chain")
+
+	_, err = verifier.FetchAttestations(images.Options{ImageRef: imageRef, CertChain: string(signer.caPEM)})
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func Test_VerifySignatureWrongTarget(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	ref, err := name.ParseReference(host + "/test/signed:v1")
+	assert.NilError(t, err)
+	desc := pushImage(t, ref)
+
+	otherRef, err := name.ParseReference(host + "/test/other:v1")
+	assert.NilError(t, err)
+	otherDesc := pushImage(t, otherRef)
+
+	// sign the other image but attach the signature to the first image
+	signer := newTestSigner(t)
+	target := targetArtifact{MediaType: string(otherDesc.MediaType), Digest: otherDesc.Digest.String(), Size: otherDesc.Size}
+	pushSignature(t, ref.Context(), desc, signer.sign(t, target))
+
+	_, err = NewVerifier().VerifySignature(images.Options{ImageRef: ref.String(), CertChain: string(signer.caPEM)})
+	assert.ErrorContains(t, err, "digest mismatch")
+}
+
+func Test_VerifySignatureUnsigned(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	ref, err := name.ParseReference(host + "/test/unsigned:v1")
+	assert.NilError(t, err)
+	pushImage(t, ref)
+
+	signer := newTestSigner(t)
+	_, err = NewVerifier().VerifySignature(images.Options{ImageRef: ref.String(), CertChain: string(signer.caPEM)})
+	assert.ErrorContains(t, err, "no signatures found")
+}
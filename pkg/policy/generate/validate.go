@@ -10,6 +10,8 @@ import (
 	commonAnchors "github.com/kyverno/kyverno/pkg/engine/anchor"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/policy/common"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Generate provides implementation to validate 'generate' rule
@@ -40,6 +42,20 @@ func (g *Generate) Validate() (string, error) {
 		return "", fmt.Errorf("only one of data or clone can be specified")
 	}
 
+	if len(rule.ForEachGeneration) > 0 {
+		return g.validateForEach(rule)
+	}
+
+	if !reflect.DeepEqual(rule.CloneList, kyvernov1.CloneList{}) {
+		if rule.GetData() != nil || rule.Clone != (kyvernov1.CloneFrom{}) {
+			return "", fmt.Errorf("only one of data, clone or cloneList can be specified")
+		}
+		if path, err := g.validateCloneList(rule.CloneList, rule.ResourceSpec); err != nil {
+			return path, err
+		}
+		return "", nil
+	}
+
 	kind, name, namespace := rule.Kind, rule.Name, rule.Namespace
 
 	if name == "" {
@@ -96,6 +112,70 @@ func (g *Generate) validateClone(c kyvernov1.CloneFrom, kind string) (string, er
 	return "", nil
 }
 
+// validateForEach checks the resource declared in each foreach is generated the same way as the resource of a rule
+func (g *Generate) validateForEach(rule kyvernov1.Generation) (string, error) {
+	if rule.ResourceSpec != (kyvernov1.ResourceSpec{}) || rule.GetData() != nil || rule.Clone != (kyvernov1.CloneFrom{}) || !reflect.DeepEqual(rule.CloneList, kyvernov1.CloneList{}) {
+		return "", fmt.Errorf("foreach cannot be specified with the resource, data, clone or cloneList of the rule")
+	}
+
+	for i, fe := range rule.ForEachGeneration {
+		if fe.List == "" {
+			return fmt.Sprintf("foreach[%d].list", i), fmt.Errorf("list cannot be empty")
+		}
+
+		checker := Generate{
+			rule: kyvernov1.Generation{
+				ResourceSpec: fe.ResourceSpec,
+				RawData:      fe.RawData,
+				Clone:        fe.Clone,
+			},
+			authCheck: g.authCheck,
+			log:       g.log,
+		}
+		if path, err := checker.Validate(); err != nil {
+			return fmt.Sprintf("foreach[%d].%s", i, path), err
+		}
+	}
+	return "", nil
+}
+
+// validateCloneList checks the generated resources take the kind and the name of their source,
+// and that kyverno can get the source resources and generate them in the target namespace
+func (g *Generate) validateCloneList(c kyvernov1.CloneList, target kyvernov1.ResourceSpec) (string, error) {
+	if target.Kind != "" {
+		return "kind", fmt.Errorf("kind cannot be specified with cloneList")
+	}
+	if target.Name != "" {
+		return "name", fmt.Errorf("name cannot be specified with cloneList")
+	}
+	if len(c.Kinds) == 0 {
+		return "cloneList.kinds", fmt.Errorf("kinds cannot be empty")
+	}
+	if c.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(c.Selector); err != nil {
+			return "cloneList.selector", fmt.Errorf("invalid label selector: %v", err)
+		}
+	}
+
+	for _, gvk := range c.Kinds {
+		_, kind := kubeutils.GetKindFromGVK(gvk)
+		// Skip if there is variable defined
+		if !variables.IsVariable(c.Namespace) {
+			ok, err := g.authCheck.CanIGet(kind, c.Namespace)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				return "", fmt.Errorf("kyverno does not have permissions to 'get' resource %s/%s. Update permissions in ClusterRole 'kyverno:generate'", kind, c.Namespace)
+			}
+		}
+		if err := g.canIGenerate(kind, target.Namespace); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
 // canIGenerate returns a error if kyverno cannot perform operations
 func (g *Generate) canIGenerate(kind, namespace string) error {
 	// Skip if there is variable defined
@@ -87,3 +87,84 @@ func Test_Validate_Generate_HasAnchors(t *testing.T) {
 		assert.Assert(t, err != nil)
 	}
 }
+
+func Test_Validate_Generate_CloneList(t *testing.T) {
+	rawGenerate := []byte(`
+	{
+		"namespace": "prod",
+		"synchronize": true,
+		"cloneList": {
+			"namespace": "default",
+			"kinds": ["v1/Secret", "v1/ConfigMap"],
+			"selector": {
+				"matchLabels": {
+					"allowedToBeCloned": "true"
+				}
+			}
+		}
+	}`)
+
+	var genRule kyverno.Generation
+	err := json.Unmarshal(rawGenerate, &genRule)
+	assert.NilError(t, err)
+	_, err = NewFakeGenerate(genRule).Validate()
+	assert.NilError(t, err)
+
+	genRule.Name = "copied-cm"
+	path, err := NewFakeGenerate(genRule).Validate()
+	assert.Equal(t, path, "name")
+	assert.Assert(t, err != nil)
+
+	genRule.Name = ""
+	genRule.Clone = kyverno.CloneFrom{Namespace: "default", Name: "game"}
+	_, err = NewFakeGenerate(genRule).Validate()
+	assert.Error(t, err, "only one of data, clone or cloneList can be specified")
+
+	genRule.Clone = kyverno.CloneFrom{}
+	genRule.CloneList.Kinds = nil
+	path, err = NewFakeGenerate(genRule).Validate()
+	assert.Equal(t, path, "cloneList.kinds")
+	assert.Assert(t, err != nil)
+}
+
+func Test_Validate_Generate_ForEach(t *testing.T) {
+	rawGenerate := []byte(`
+	{
+		"synchronize": true,
+		"foreach": [
+			{
+				"list": "request.object.spec.ports",
+				"kind": "NetworkPolicy",
+				"namespace": "{{request.object.metadata.namespace}}",
+				"name": "{{request.object.metadata.name}}-{{element.port}}",
+				"data": {
+					"spec": {
+						"podSelector": {}
+					}
+				}
+			}
+		]
+	}`)
+
+	var genRule kyverno.Generation
+	err := json.Unmarshal(rawGenerate, &genRule)
+	assert.NilError(t, err)
+	_, err = NewFakeGenerate(genRule).Validate()
+	assert.NilError(t, err)
+
+	genRule.ForEachGeneration[0].Name = ""
+	path, err := NewFakeGenerate(genRule).Validate()
+	assert.Equal(t, path, "foreach[0].name")
+	assert.Assert(t, err != nil)
+
+	genRule.ForEachGeneration[0].List = ""
+	path, err = NewFakeGenerate(genRule).Validate()
+	assert.Equal(t, path, "foreach[0].list")
+	assert.Assert(t, err != nil)
+
+	genRule.ForEachGeneration[0].List = "request.object.spec.ports"
+	genRule.ForEachGeneration[0].Name = "netpol"
+	genRule.Kind = "NetworkPolicy"
+	_, err = NewFakeGenerate(genRule).Validate()
+	assert.Assert(t, err != nil)
+}
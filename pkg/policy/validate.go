@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/utils"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -499,6 +501,10 @@ func buildContext(rule *kyvernov1.Rule, background bool) *context.MockContext {
 		addContextVariables(fe.Context, ctx)
 	}
 
+	for _, fe := range rule.Generation.ForEachGeneration {
+		addContextVariables(fe.Context, ctx)
+	}
+
 	return ctx
 }
 
@@ -512,7 +518,7 @@ func getAllowedVariables(background bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *context.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.Service != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -756,6 +762,35 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 			}
 		}
 	}
+	// validating the regex patterns present under foreach preconditions and conditions, if they exist
+	for i, fe := range rule.Validation.ForEachValidation {
+		if fe.AnyAllConditions != nil {
+			if path, err := validateRegexConditions(*fe.AnyAllConditions, "preconditions"); err != nil {
+				return fmt.Sprintf("validate.foreach[%d].%s", i, path), err
+			}
+		}
+		if fe.Deny != nil {
+			if target := fe.Deny.GetAnyAllConditions(); target != nil {
+				if path, err := validateRegexConditions(target, "conditions"); err != nil {
+					return fmt.Sprintf("validate.foreach[%d].deny.%s", i, path), err
+				}
+			}
+		}
+	}
+	for i, fe := range rule.Mutation.ForEachMutation {
+		if fe.AnyAllConditions != nil {
+			if path, err := validateRegexConditions(*fe.AnyAllConditions, "preconditions"); err != nil {
+				return fmt.Sprintf("mutate.foreach[%d].%s", i, path), err
+			}
+		}
+	}
+	for i, fe := range rule.Generation.ForEachGeneration {
+		if fe.AnyAllConditions != nil {
+			if path, err := validateRegexConditions(*fe.AnyAllConditions, "preconditions"); err != nil {
+				return fmt.Sprintf("generate.foreach[%d].%s", i, path), err
+			}
+		}
+	}
 	return "", nil
 }
 
@@ -814,6 +849,11 @@ func validateConditionValues(c kyvernov1.Condition) (string, error) {
 	if k == nil || v == nil || c.Operator == "" {
 		return "", fmt.Errorf("entered value of `key`, `value` or `operator` is missing or misspelled")
 	}
+	if operator.IsRegexOperator(c.Operator) {
+		if path, err := validateRegexPatterns(c); err != nil {
+			return path, err
+		}
+	}
 	switch reflect.TypeOf(k).Kind() {
 	case reflect.String:
 		value, err := validateValuesKeyRequest(c)
@@ -823,6 +863,59 @@ func validateConditionValues(c kyvernov1.Condition) (string, error) {
 	}
 }
 
+// validateRegexConditions only validates the regex patterns of the given 'conditions' or 'preconditions',
+// it is used for foreach entries whose other condition values are only known when the element is evaluated
+func validateRegexConditions(conditions apiextensions.JSON, schemaKey string) (string, error) {
+	kyvernoConditions, err := utils.ApiextensionsJsonToKyvernoConditions(conditions)
+	if err != nil {
+		return schemaKey, err
+	}
+	switch typedConditions := kyvernoConditions.(type) {
+	case kyvernov1.AnyAllConditions:
+		for i, condition := range typedConditions.AnyConditions {
+			if !operator.IsRegexOperator(condition.Operator) {
+				continue
+			}
+			if path, err := validateRegexPatterns(condition); err != nil {
+				return fmt.Sprintf("%s.any[%d].%s", schemaKey, i, path), err
+			}
+		}
+		for i, condition := range typedConditions.AllConditions {
+			if !operator.IsRegexOperator(condition.Operator) {
+				continue
+			}
+			if path, err := validateRegexPatterns(condition); err != nil {
+				return fmt.Sprintf("%s.all[%d].%s", schemaKey, i, path), err
+			}
+		}
+	case []kyvernov1.Condition: // backwards compatibility
+		for i, condition := range typedConditions {
+			if !operator.IsRegexOperator(condition.Operator) {
+				continue
+			}
+			if path, err := validateRegexPatterns(condition); err != nil {
+				return fmt.Sprintf("%s[%d].%s", schemaKey, i, path), err
+			}
+		}
+	}
+	return "", nil
+}
+
+// validateRegexPatterns validates that the values of a regex operator are valid regular expressions,
+// so that invalid patterns are rejected when the policy is created instead of when it is evaluated
+func validateRegexPatterns(c kyvernov1.Condition) (string, error) {
+	patterns, err := operator.RegexPatterns(c)
+	if err != nil {
+		return "value", err
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return "value", fmt.Errorf("invalid regular expression '%s': %v", pattern, err)
+		}
+	}
+	return "", nil
+}
+
 func validateValuesKeyRequest(c kyvernov1.Condition) (string, error) {
 	k := c.GetKey()
 	switch strings.ReplaceAll(k.(string), " ", "") {
@@ -884,16 +977,18 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		}
 
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil && entry.APICall == nil && entry.Service == nil && entry.ImageRegistry == nil && entry.Variable == nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.Service == nil && entry.ImageRegistry == nil && entry.Variable == nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.Service != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+			err = validateServiceCall(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.Service == nil && entry.ImageRegistry != nil && entry.Variable == nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.Service == nil && entry.ImageRegistry == nil && entry.Variable != nil {
 			err = validateVariable(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			return fmt.Errorf("exactly one of configMap or apiCall or service or imageRegistry or variable is required for context entries")
 		}
 
 		if err != nil {
@@ -935,6 +1030,20 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 }
 
 func validateAPICall(entry kyvernov1.ContextEntry) error {
+	if len(entry.APICall.Data) > 0 && entry.APICall.Method != kyvernov1.MethodPost {
+		return fmt.Errorf("data is only supported for POST requests in apiCall context entry %s", entry.Name)
+	}
+
+	if len(entry.APICall.Data) == 0 && entry.APICall.Method == kyvernov1.MethodPost {
+		return fmt.Errorf("data is required for POST requests in apiCall context entry %s", entry.Name)
+	}
+
+	for _, d := range entry.APICall.Data {
+		if d.Key == "" {
+			return fmt.Errorf("a key is required for data in apiCall context entry %s", entry.Name)
+		}
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
 	// Skip validation if a variable is detected
@@ -950,6 +1059,32 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateServiceCall(entry kyvernov1.ContextEntry) error {
+	if entry.Service.URL == "" {
+		return fmt.Errorf("a URL is required for service context entry")
+	}
+
+	if entry.Service.CABundle != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(entry.Service.CABundle)) {
+			return fmt.Errorf("failed to parse PEM caBundle for service context entry")
+		}
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
+	// will be inserted by the variable
+	// Skip validation if a variable is detected
+	for _, expr := range []string{entry.Service.JMESPath, entry.Service.Body} {
+		jmesPath := variables.ReplaceAllVars(expr, func(s string) string { return "kyvernojmespathvariable" })
+		if !strings.Contains(jmesPath, "kyvernojmespathvariable") && expr != "" {
+			if _, err := jmespath.NewParser().Parse(expr); err != nil {
+				return fmt.Errorf("failed to parse JMESPath %s: %v", expr, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func validateImageRegistry(entry kyvernov1.ContextEntry) error {
 	if entry.ImageRegistry.Reference == "" {
 		return fmt.Errorf("a ref is required for imageRegistry context entry")
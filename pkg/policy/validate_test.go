@@ -194,6 +194,138 @@ func Test_Validate_PreconditionsValuesString_KeyRequestOperation_UnknownValue(t
 	assert.Assert(t, err != nil)
 }
 
+func Test_Validate_Conditions_RegexMatches(t *testing.T) {
+	conditions := []byte(`
+	{
+		"any": [
+			{
+				"key": "{{ request.object.metadata.name }}",
+				"operator": "RegexMatches",
+				"value": "^nginx-[0-9]+$"
+			},
+			{
+				"key": "{{ request.object.spec.containers[].image }}",
+				"operator": "AnyRegexMatches",
+				"value": ["^ghcr\\.io/", "^{{ request.object.metadata.namespace }}/"]
+			}
+		]
+	}
+	`)
+
+	var pcs apiextensions.JSON
+	err := json.Unmarshal(conditions, &pcs)
+	assert.NilError(t, err)
+
+	_, err = validateConditions(pcs, "preconditions")
+	assert.NilError(t, err)
+}
+
+func Test_Validate_Conditions_RegexMatches_InvalidPattern(t *testing.T) {
+	conditions := []byte(`
+	{
+		"all": [
+			{
+				"key": "{{ request.object.spec.containers[].image }}",
+				"operator": "AllRegexMatches",
+				"value": ["^ghcr\\.io/", "^docker.io/(library"]
+			}
+		]
+	}
+	`)
+
+	var dcs apiextensions.JSON
+	err := json.Unmarshal(conditions, &dcs)
+	assert.NilError(t, err)
+
+	path, err := validateConditions(dcs, "conditions")
+	assert.ErrorContains(t, err, "invalid regular expression '^docker.io/(library'")
+	assert.Equal(t, path, "conditions.all[0].value")
+}
+
+func Test_Validate_ForEach_RegexMatches_InvalidPattern(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "restrict-registries"
+		},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"background": false,
+			"rules": [
+				{
+					"name": "restrict-registries",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"validate": {
+						"message": "Images must come from an allowed registry.",
+						"foreach": [
+							{
+								"list": "request.object.spec.containers",
+								"deny": {
+									"conditions": {
+										"any": [
+											{
+												"key": "{{ element.image }}",
+												"operator": "RegexMatches",
+												"value": "^docker.io/[a-z"
+											}
+										]
+									}
+								}
+							}
+						]
+					}
+				}
+			]
+		}
+	}`)
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	openAPIController, _ := openapi.NewOpenAPIController()
+	_, err = Validate(policy, nil, true, openAPIController)
+	assert.ErrorContains(t, err, "invalid regular expression '^docker.io/[a-z'")
+}
+
+func Test_Validate_RegexConditions_OnlyChecksRegexOperators(t *testing.T) {
+	conditions := []byte(`
+	{
+		"all": [
+			{
+				"key": "{{ request.operation }}",
+				"operator": "Equals",
+				"value": "UNKNOWN"
+			},
+			{
+				"key": "{{ element.image }}",
+				"operator": "AllRegexMatches",
+				"value": ["^ghcr\\.io/", "^docker.io/(library"]
+			}
+		]
+	}
+	`)
+
+	var pcs apiextensions.JSON
+	err := json.Unmarshal(conditions, &pcs)
+	assert.NilError(t, err)
+
+	path, err := validateRegexConditions(pcs, "preconditions")
+	assert.ErrorContains(t, err, "invalid regular expression '^docker.io/(library'")
+	assert.Equal(t, path, "preconditions.all[1].value")
+
+	var operationConditions apiextensions.JSON
+	err = json.Unmarshal([]byte(`{"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "UNKNOWN"}]}`), &operationConditions)
+	assert.NilError(t, err)
+
+	_, err = validateRegexConditions(operationConditions, "preconditions")
+	assert.NilError(t, err)
+}
+
 func Test_Validate_DenyConditionsValuesList_KeyRequestOperation_ExpectedItem(t *testing.T) {
 	denyConditions := []byte(`
 	[
@@ -1020,6 +1152,16 @@ func Test_Validate_ApiCall(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			resource: kyverno.ContextEntry{
+				Name: "review",
+				APICall: &kyverno.APICall{
+					URLPath: "/apis/authorization.k8s.io/v1/subjectaccessreviews",
+					Method:  kyverno.MethodPost,
+				},
+			},
+			expectedResult: "data is required for POST requests in apiCall context entry review",
+		},
 	}
 
 	for _, testCase := range testCases {
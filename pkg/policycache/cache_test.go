@@ -6,6 +6,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"gotest.tools/assert"
 	kubecache "k8s.io/client-go/tools/cache"
 )
@@ -1104,6 +1105,41 @@ func Test_Generate_Policy(t *testing.T) {
 	}
 }
 
+func Test_Generate_CloneList_Policy(t *testing.T) {
+	pCache := newPolicyCache()
+	var policy *kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"metadata": {"name": "sync-configmaps"},
+		"spec": {
+			"rules": [{
+				"name": "sync-configmaps",
+				"match": {"resources": {"kinds": ["Namespace"]}},
+				"generate": {
+					"namespace": "{{request.object.metadata.name}}",
+					"synchronize": true,
+					"cloneList": {
+						"namespace": "default",
+						"kinds": ["v1/ConfigMap", "v1/Secret"],
+						"selector": {"matchLabels": {"allowedToBeCloned": "true"}}
+					}
+				}
+			}]
+		}
+	}`), &policy))
+
+	//add
+	setPolicy(pCache, policy)
+	for _, kind := range []string{"ConfigMap", "Secret"} {
+		assert.Equal(t, len(pCache.get(GenerateCloneSource, kind, "")), 1)
+		assert.Equal(t, len(pCache.get(Generate, kind, "")), 0)
+	}
+	assert.Equal(t, len(pCache.get(GenerateCloneSource, "Namespace", "")), 0)
+
+	//remove
+	unsetPolicy(pCache, policy)
+	assert.Equal(t, len(pCache.get(GenerateCloneSource, "ConfigMap", "")), 0)
+}
+
 func Test_NsMutate_Policy(t *testing.T) {
 	pCache := newPolicyCache()
 	policy := newMutatePolicy(t)
@@ -1159,3 +1195,125 @@ func Test_Validate_Enforce_Policy(t *testing.T) {
 		t.Errorf("removing: expected 0 validate audit policy, found %v", len(validateAudit))
 	}
 }
+
+func newRegexPolicy(t *testing.T) *kyvernov1.ClusterPolicy {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "restrict-registries"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "restrict-registries",
+					"match": {
+						"resources": {
+							"kinds": ["Pod"]
+						}
+					},
+					"preconditions": {
+						"all": [
+							{
+								"key": "{{ request.object.metadata.name }}",
+								"operator": "RegexMatches",
+								"value": "^app-"
+							}
+						]
+					},
+					"validate": {
+						"foreach": [
+							{
+								"list": "request.object.spec.containers",
+								"deny": {
+									"conditions": {
+										"any": [
+											{
+												"key": "{{ element.image }}",
+												"operator": "RegexMatches",
+												"value": ["^ghcr\\.io/", "^{{ request.namespace }}/"]
+											}
+										]
+									}
+								}
+							}
+						]
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	return policy
+}
+
+func Test_Regex_Patterns(t *testing.T) {
+	policy := newRegexPolicy(t)
+	patterns := regexPatterns(policy)
+	assert.DeepEqual(t, patterns, []string{"^app-", "^ghcr\\.io/"})
+
+	pCache := newPolicyCache()
+	setPolicy(pCache, policy)
+	cached, err := operator.DefaultRegexCache.Compile("^ghcr\\.io/")
+	assert.NilError(t, err)
+	again, err := operator.DefaultRegexCache.Compile("^ghcr\\.io/")
+	assert.NilError(t, err)
+	assert.Assert(t, cached == again)
+
+	unsetPolicy(pCache, policy)
+	compiled, err := operator.DefaultRegexCache.Compile("^ghcr\\.io/")
+	assert.NilError(t, err)
+	assert.Assert(t, cached != compiled)
+}
+
+func Test_Regex_Patterns_Generate_ForEach(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "generate-per-port"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "generate-per-port",
+					"match": {
+						"resources": {
+							"kinds": ["Service"]
+						}
+					},
+					"generate": {
+						"foreach": [
+							{
+								"list": "request.object.spec.ports",
+								"preconditions": {
+									"any": [
+										{
+											"key": "{{ element.name }}",
+											"operator": "RegexMatches",
+											"value": "^http"
+										}
+									]
+								},
+								"kind": "ConfigMap",
+								"name": "{{ element.name }}",
+								"namespace": "default",
+								"data": {}
+							}
+						]
+					}
+				}
+			]
+		}
+	}`)
+
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, regexPatterns(policy), []string{"^http"})
+}
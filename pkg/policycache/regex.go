@@ -0,0 +1,84 @@
+package policycache
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
+	"github.com/kyverno/kyverno/pkg/utils"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// regexPatterns returns the regular expressions used by the regex operators in the conditions of a policy
+func regexPatterns(policy kyvernov1.PolicyInterface) []string {
+	var patterns []string
+	// autogen rules share the conditions of the original rule
+	seen := sets.NewString()
+	addConditions := func(conditions interface{}) {
+		for _, condition := range flattenConditions(conditions) {
+			p, err := operator.RegexPatterns(condition)
+			if err != nil {
+				continue
+			}
+			for _, pattern := range p {
+				if !seen.Has(pattern) {
+					seen.Insert(pattern)
+					patterns = append(patterns, pattern)
+				}
+			}
+		}
+	}
+	addJSONConditions := func(conditions apiextensions.JSON) {
+		if conditions == nil {
+			return
+		}
+		kyvernoConditions, err := utils.ApiextensionsJsonToKyvernoConditions(conditions)
+		if err != nil {
+			return
+		}
+		addConditions(kyvernoConditions)
+	}
+	for _, rule := range autogen.ComputeRules(policy) {
+		addJSONConditions(rule.GetAnyAllConditions())
+		if rule.Validation.Deny != nil {
+			addJSONConditions(rule.Validation.Deny.GetAnyAllConditions())
+		}
+		for _, fe := range rule.Validation.ForEachValidation {
+			if fe.AnyAllConditions != nil {
+				addConditions(*fe.AnyAllConditions)
+			}
+			if fe.Deny != nil {
+				addJSONConditions(fe.Deny.GetAnyAllConditions())
+			}
+		}
+		for _, fe := range rule.Mutation.ForEachMutation {
+			if fe.AnyAllConditions != nil {
+				addConditions(*fe.AnyAllConditions)
+			}
+		}
+		for _, fe := range rule.Generation.ForEachGeneration {
+			if fe.AnyAllConditions != nil {
+				addConditions(*fe.AnyAllConditions)
+			}
+		}
+		for _, iv := range rule.VerifyImages {
+			for _, attestation := range iv.Attestations {
+				for _, conditions := range attestation.Conditions {
+					addConditions(conditions)
+				}
+			}
+		}
+	}
+	return patterns
+}
+
+func flattenConditions(conditions interface{}) []kyvernov1.Condition {
+	switch typedConditions := conditions.(type) {
+	case kyvernov1.AnyAllConditions:
+		return append(append([]kyvernov1.Condition{}, typedConditions.AnyConditions...), typedConditions.AllConditions...)
+	case []kyvernov1.Condition: // backwards compatibility
+		return typedConditions
+	default:
+		return nil
+	}
+}
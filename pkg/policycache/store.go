@@ -5,6 +5,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"github.com/kyverno/kyverno/pkg/policy"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,6 +35,7 @@ func (pc *policyCache) set(key string, policy kyvernov1.PolicyInterface) {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
 	pc.store.set(key, policy)
+	operator.DefaultRegexCache.Set(key, regexPatterns(policy))
 	logger.V(4).Info("policy is added to cache", "key", key)
 }
 
@@ -41,6 +43,7 @@ func (pc *policyCache) unset(key string) {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
 	pc.store.unset(key)
+	operator.DefaultRegexCache.Unset(key)
 	logger.V(4).Info("policy is removed from cache", "key", key)
 }
 
@@ -97,7 +100,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface) {
 	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
 	m.policies[key] = policy
 	type state struct {
-		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks, hasVerifyYAML bool
+		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks, hasVerifyYAML, hasCloneListSource bool
 	}
 	kindStates := map[string]state{}
 	for _, rule := range autogen.ComputeRules(policy) {
@@ -111,6 +114,14 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface) {
 			entry.hasImagesValidationChecks = (entry.hasImagesValidationChecks || rule.HasImagesValidationChecks())
 			kindStates[kind] = entry
 		}
+		if rule.HasGenerate() && !rule.Generation.CloneList.IsEmpty() {
+			for _, gvk := range rule.Generation.CloneList.Kinds {
+				kind := computeKind(gvk)
+				entry := kindStates[kind]
+				entry.hasCloneListSource = true
+				kindStates[kind] = entry
+			}
+		}
 	}
 	for kind, state := range kindStates {
 		if m.kindType[kind] == nil {
@@ -122,6 +133,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface) {
 				VerifyImagesMutate:   sets.NewString(),
 				VerifyImagesValidate: sets.NewString(),
 				VerifyYAML:           sets.NewString(),
+				GenerateCloneSource:  sets.NewString(),
 			}
 		}
 		m.kindType[kind][Mutate] = set(m.kindType[kind][Mutate], key, state.hasMutate)
@@ -131,6 +143,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface) {
 		m.kindType[kind][VerifyImagesMutate] = set(m.kindType[kind][VerifyImagesMutate], key, state.hasVerifyImages)
 		m.kindType[kind][VerifyImagesValidate] = set(m.kindType[kind][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks)
 		m.kindType[kind][VerifyYAML] = set(m.kindType[kind][VerifyYAML], key, state.hasVerifyYAML)
+		m.kindType[kind][GenerateCloneSource] = set(m.kindType[kind][GenerateCloneSource], key, state.hasCloneListSource)
 	}
 }
 
@@ -12,4 +12,6 @@ const (
 	VerifyImagesMutate
 	VerifyImagesValidate
 	VerifyYAML
+	// GenerateCloneSource is set for the kinds of the sources of a generate rule clone list
+	GenerateCloneSource
 )
@@ -3,9 +3,12 @@ package registryclient
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 
 	ecr "github.com/awslabs/amazon-ecr-credential-helper/ecr-login"
 	"github.com/chrismellard/docker-credential-acr-env/pkg/credhelper"
@@ -15,6 +18,8 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/google"
 	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/pkg/errors"
 	"github.com/sigstore/cosign/pkg/oci/remote"
 	"k8s.io/client-go/kubernetes"
@@ -35,6 +40,13 @@ type Client interface {
 	// and provides access to metadata about remote artifact.
 	FetchImageDescriptor(imageRef string) (*gcrremote.Descriptor, error)
 
+	// FetchReferrers lists the artifacts of the given artifact type that refer to the image digest.
+	// The OCI referrers API is used when the registry supports it, otherwise the referrers tag schema.
+	FetchReferrers(imageRef string, artifactType string) ([]Referrer, error)
+
+	// FetchBlob fetches the content of the blob with given reference (repository@digest).
+	FetchBlob(blobRef string) ([]byte, error)
+
 	// UseLocalKeychain updates keychain with the default local keychain.
 	UseLocalKeychain()
 
@@ -132,7 +144,7 @@ func (c *client) FetchImageDescriptor(imageRef string) (*gcrremote.Descriptor, e
 		return nil, fmt.Errorf("failed to parse image reference: %s, error: %v", imageRef, err)
 	}
 
-	desc, err := gcrremote.Get(parsedRef, gcrremote.WithAuthFromKeychain(c.keychain))
+	desc, err := gcrremote.Get(parsedRef, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithTransport(c.transport))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image reference: %s, error: %v", imageRef, err)
 	}
@@ -140,6 +152,138 @@ func (c *client) FetchImageDescriptor(imageRef string) (*gcrremote.Descriptor, e
 	return desc, nil
 }
 
+// Referrer is a descriptor of an artifact (e.g. a signature) that refers to an image manifest.
+type Referrer struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+type referrersIndex struct {
+	Manifests []Referrer `json:"manifests"`
+}
+
+// FetchReferrers lists the artifacts of the given artifact type that refer to the image digest.
+// The OCI referrers API is used when the registry supports it, otherwise the referrers tag schema.
+func (c *client) FetchReferrers(imageRef string, artifactType string) ([]Referrer, error) {
+	ref, err := name.NewDigest(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image digest: %s, error: %v", imageRef, err)
+	}
+
+	index, err := c.fetchReferrersIndex(ref)
+	if err != nil {
+		var terr *transport.Error
+		if !errors.As(err, &terr) || terr.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to fetch referrers: %s, error: %v", imageRef, err)
+		}
+
+		index, err = c.fetchReferrersTagIndex(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch referrers: %s, error: %v", imageRef, err)
+		}
+	}
+
+	var referrers []Referrer
+	for _, r := range index.Manifests {
+		if artifactType == "" || r.ArtifactType == artifactType {
+			referrers = append(referrers, r)
+		}
+	}
+
+	return referrers, nil
+}
+
+// fetchReferrersIndex queries the referrers API of the registry.
+// See: https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func (c *client) fetchReferrersIndex(ref name.Digest) (*referrersIndex, error) {
+	reg := ref.Context().Registry
+	auth, err := c.keychain.Resolve(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := transport.NewWithContext(context.Background(), reg, auth, c.transport, []string{ref.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, err
+	}
+
+	u := url.URL{
+		Scheme: reg.Scheme(),
+		Host:   reg.RegistryStr(),
+		Path:   fmt.Sprintf("/v2/%s/referrers/%s", ref.Context().RepositoryStr(), ref.DigestStr()),
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", string(types.OCIImageIndex))
+	resp, err := (&http.Client{Transport: tr}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	if err := transport.CheckError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var index referrersIndex
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, errors.Wrap(err, "failed to decode referrers index")
+	}
+
+	return &index, nil
+}
+
+// fetchReferrersTagIndex fetches the index tagged with the referrers tag schema (<alg>-<ref>).
+// A missing tag means the image does not have any referrers.
+// See: https://github.com/opencontainers/distribution-spec/blob/main/spec.md#referrers-tag-schema
+func (c *client) fetchReferrersTagIndex(ref name.Digest) (*referrersIndex, error) {
+	tag := ref.Context().Tag(strings.Replace(ref.DigestStr(), ":", "-", 1))
+	desc, err := gcrremote.Get(tag, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithTransport(c.transport))
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return &referrersIndex{}, nil
+		}
+
+		return nil, err
+	}
+
+	var index referrersIndex
+	if err := json.Unmarshal(desc.Manifest, &index); err != nil {
+		return nil, errors.Wrap(err, "failed to decode referrers index")
+	}
+
+	return &index, nil
+}
+
+// FetchBlob fetches the content of the blob with given reference (repository@digest).
+func (c *client) FetchBlob(blobRef string) ([]byte, error) {
+	ref, err := name.NewDigest(blobRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blob reference: %s, error: %v", blobRef, err)
+	}
+
+	layer, err := gcrremote.Layer(ref, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithTransport(c.transport))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob: %s, error: %v", blobRef, err)
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob: %s, error: %v", blobRef, err)
+	}
+
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
 // RefreshKeychainPullSecrets loads fresh data from pull secrets and updates Keychain.
 // If pull secrets are empty - returns.
 func (c *client) RefreshKeychainPullSecrets() error {
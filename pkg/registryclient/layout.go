@@ -0,0 +1,130 @@
+package registryclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+)
+
+// LayoutRefAnnotation is the annotation giving the image reference of the manifests in an OCI image layout.
+const LayoutRefAnnotation = "org.opencontainers.image.ref.name"
+
+// InitLayoutClient initializes a registry client with given options that serves the images of an OCI image layout
+// instead of the remote registries. Each image or index of the layout is served with the full image reference
+// (e.g. ghcr.io/kyverno/test-verify-image:signed) of its org.opencontainers.image.ref.name annotation, so that
+// signatures and attestations can be stored with their cosign tags. All requests of the client are sent to the
+// layout, images of different registries must not share the same repository.
+// The returned function stops the registry serving the layout, it must be called once the client is no longer used.
+func InitLayoutClient(path string, options ...Option) (Client, func(), error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load OCI layout %s", path)
+	}
+
+	index, err := p.ImageIndex()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load OCI layout %s", path)
+	}
+
+	server := httptest.NewUnstartedServer(registry.New(registry.Logger(log.New(ioutil.Discard, "", 0))))
+	server.StartTLS()
+	transport := layoutTransport(server.Listener.Addr().String())
+	cleanup := func() {
+		transport.CloseIdleConnections()
+		server.Close()
+	}
+
+	if err := pushLayout(index, transport); err != nil {
+		cleanup()
+		return nil, nil, errors.Wrapf(err, "failed to load OCI layout %s", path)
+	}
+
+	c, err := InitClient(append(options, withLayoutTransport(transport))...)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return c, cleanup, nil
+}
+
+// withLayoutTransport provides initialize registry client option that sends all requests
+// with the transport of the registry serving an OCI image layout.
+func withLayoutTransport(transport *http.Transport) Option {
+	return func(c *client) error {
+		c.transport = transport
+		c.keychain = authn.NewMultiKeychain()
+		c.baseKeychain = c.keychain
+		return nil
+	}
+}
+
+// layoutTransport sends all requests to the registry serving the OCI image layout,
+// whatever the registry of the image reference.
+func layoutTransport(addr string) *http.Transport {
+	dialer := &net.Dialer{}
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		},
+		// the certificate of the local registry does not match the registries of the image references
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+	}
+}
+
+func pushLayout(index v1.ImageIndex, transport *http.Transport) error {
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return err
+	}
+
+	for _, desc := range manifest.Manifests {
+		refName, ok := desc.Annotations[LayoutRefAnnotation]
+		if !ok {
+			continue
+		}
+
+		ref, err := name.ParseReference(refName)
+		if err != nil {
+			return fmt.Errorf("invalid image reference %s for %s: %v", refName, desc.Digest, err)
+		}
+
+		switch {
+		case desc.MediaType.IsIndex():
+			child, err := index.ImageIndex(desc.Digest)
+			if err != nil {
+				return err
+			}
+
+			if err := gcrremote.WriteIndex(ref, child, gcrremote.WithTransport(transport)); err != nil {
+				return fmt.Errorf("failed to push %s: %v", refName, err)
+			}
+		case desc.MediaType.IsImage():
+			img, err := index.Image(desc.Digest)
+			if err != nil {
+				return err
+			}
+
+			if err := gcrremote.Write(ref, img, gcrremote.WithTransport(transport)); err != nil {
+				return fmt.Errorf("failed to push %s: %v", refName, err)
+			}
+		default:
+			return fmt.Errorf("unsupported media type %s for %s", desc.MediaType, refName)
+		}
+	}
+
+	return nil
+}
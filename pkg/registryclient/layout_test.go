@@ -0,0 +1,44 @@
+package registryclient
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"gotest.tools/assert"
+)
+
+func TestInitLayoutClient(t *testing.T) {
+	dir := t.TempDir()
+	p, err := layout.Write(dir, empty.Index)
+	assert.NilError(t, err)
+
+	img, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, p.AppendImage(img, layout.WithAnnotations(map[string]string{LayoutRefAnnotation: "ghcr.io/kyverno/test-image:v1"})))
+
+	digest, err := img.Digest()
+	assert.NilError(t, err)
+
+	c, cleanup, err := InitLayoutClient(dir)
+	assert.NilError(t, err)
+	defer cleanup()
+
+	desc, err := c.FetchImageDescriptor("ghcr.io/kyverno/test-image:v1")
+	assert.NilError(t, err)
+	assert.Equal(t, desc.Digest, digest)
+
+	_, err = c.FetchImageDescriptor("ghcr.io/kyverno/test-image:v2")
+	assert.ErrorContains(t, err, "MANIFEST_UNKNOWN")
+
+	// the registry serving the layout is stopped by the cleanup function
+	cleanup()
+	_, err = c.FetchImageDescriptor("ghcr.io/kyverno/test-image:v1")
+	assert.Assert(t, err != nil)
+}
+
+func TestInitLayoutClientWithInvalidLayout(t *testing.T) {
+	_, _, err := InitLayoutClient(t.TempDir())
+	assert.ErrorContains(t, err, "failed to load OCI layout")
+}
@@ -3,7 +3,9 @@ package webhookconfig
 import (
 	"context"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -70,6 +72,12 @@ type webhookConfigManager struct {
 
 	createDefaultWebhook chan<- string
 
+	// configSelectors are the selectors of the Kyverno ConfigMap, combined with the selectors of the policies
+	configSelectors webhookSelectors
+	// policySelectors are the selectors of the policies of the last built webhooks, keyed by webhook name
+	policySelectors     map[string]webhookSelectors
+	configSelectorsLock sync.RWMutex
+
 	stopCh <-chan struct{}
 
 	log logr.Logger
@@ -77,6 +85,8 @@ type webhookConfigManager struct {
 
 type manage interface {
 	start()
+	updateConfigSelectors(config.WebhookConfig)
+	webhookSelectors(webhookName string) webhookSelectors
 }
 
 func newWebhookConfigManager(
@@ -227,6 +237,54 @@ func (m *webhookConfigManager) deleteValidatingWebhook(obj interface{}) {
 	}
 }
 
+// updateConfigSelectors sets the selectors of the Kyverno ConfigMap and re-builds the webhooks if they changed
+func (m *webhookConfigManager) updateConfigSelectors(webhookCfg config.WebhookConfig) {
+	selectors := webhookSelectors{
+		namespaceSelector: webhookCfg.NamespaceSelector,
+		objectSelector:    webhookCfg.ObjectSelector,
+	}
+
+	m.configSelectorsLock.Lock()
+	changed := !reflect.DeepEqual(m.configSelectors, selectors)
+	m.configSelectors = selectors
+	m.configSelectorsLock.Unlock()
+
+	if changed && m.autoUpdateWebhooks {
+		m.log.V(4).Info("webhook selectors of the Kyverno ConfigMap changed, re-building webhooks")
+		m.enqueueAllPolicies()
+	}
+}
+
+// getSelectors records the selectors of the policies of a webhook and returns them combined with the
+// selectors of the Kyverno ConfigMap
+func (m *webhookConfigManager) getSelectors(webhookName string, wh *webhook) webhookSelectors {
+	m.configSelectorsLock.Lock()
+	defer m.configSelectorsLock.Unlock()
+	if m.policySelectors == nil {
+		m.policySelectors = map[string]webhookSelectors{}
+	}
+	m.policySelectors[webhookName] = webhookSelectors{
+		namespaceSelector: wh.namespaceSelector,
+		objectSelector:    wh.objectSelector,
+	}
+	return webhookSelectors{
+		namespaceSelector: andSelectors(m.configSelectors.namespaceSelector, wh.namespaceSelector),
+		objectSelector:    andSelectors(m.configSelectors.objectSelector, wh.objectSelector),
+	}
+}
+
+// webhookSelectors returns the selectors of the Kyverno ConfigMap combined with the selectors of the
+// policies last registered in the webhook, if any
+func (m *webhookConfigManager) webhookSelectors(webhookName string) webhookSelectors {
+	m.configSelectorsLock.RLock()
+	defer m.configSelectorsLock.RUnlock()
+	policySelectors := m.policySelectors[webhookName]
+	return webhookSelectors{
+		namespaceSelector: andSelectors(m.configSelectors.namespaceSelector, policySelectors.namespaceSelector),
+		objectSelector:    andSelectors(m.configSelectors.objectSelector, policySelectors.objectSelector),
+	}
+}
+
 func (m *webhookConfigManager) enqueueAllPolicies() {
 	logger := m.log.WithName("enqueueAllPolicies")
 	policies, err := m.listAllPolicies()
@@ -379,6 +437,30 @@ func (m *webhookConfigManager) buildWebhooks(namespace string) (res []*webhook,
 	validateIgnore := newWebhook(kindValidating, DefaultWebhookTimeout, kyvernov1.Ignore)
 	validateFail := newWebhook(kindValidating, DefaultWebhookTimeout, kyvernov1.Fail)
 
+	policies, err := m.listAllPolicies()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list current policies")
+	}
+
+	// policies using a dedicated webhook are registered in their own webhooks, the background
+	// processing of the requests is still performed by the shared validating webhook
+	var shared []kyvernov1.PolicyInterface
+	for _, p := range policies {
+		if !p.GetSpec().UseDedicatedWebhook {
+			shared = append(shared, p)
+			continue
+		}
+
+		res = append(res, m.buildDedicatedWebhooks(p)...)
+		if rules := backgroundRules(p); len(rules) > 0 {
+			if p.GetSpec().GetFailurePolicy() == kyvernov1.Ignore {
+				m.mergeWebhookRules(validateIgnore, p, rules, true)
+			} else {
+				m.mergeWebhookRules(validateFail, p, rules, true)
+			}
+		}
+	}
+
 	if atomic.LoadInt64(&m.wildcardPolicy) != 0 {
 		for _, w := range []*webhook{mutateIgnore, mutateFail, validateIgnore, validateFail} {
 			setWildcardConfig(w)
@@ -388,12 +470,7 @@ func (m *webhookConfigManager) buildWebhooks(namespace string) (res []*webhook,
 		return append(res, mutateIgnore, mutateFail, validateIgnore, validateFail), nil
 	}
 
-	policies, err := m.listAllPolicies()
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to list current policies")
-	}
-
-	for _, p := range policies {
+	for _, p := range shared {
 		spec := p.GetSpec()
 		if spec.HasValidate() || spec.HasGenerate() || spec.HasMutate() || spec.HasImagesValidationChecks() || spec.HasYAMLSignatureVerify() {
 			if spec.GetFailurePolicy() == kyvernov1.Ignore {
@@ -420,17 +497,22 @@ func (m *webhookConfigManager) updateWebhookConfig(webhooks []*webhook) error {
 	logger := m.log.WithName("updateWebhookConfig")
 
 	webhooksMap := map[string]*webhook{}
+	var dedicated []*webhook
 	for _, w := range webhooks {
-		webhooksMap[webhookKey(w.kind, string(w.failurePolicy))] = w
+		if w.policyKey != "" {
+			dedicated = append(dedicated, w)
+		} else {
+			webhooksMap[webhookKey(w.kind, string(w.failurePolicy))] = w
+		}
 	}
 
 	var errs []string
-	if err := m.updateMutatingWebhookConfiguration(getResourceMutatingWebhookConfigName(m.serverIP), webhooksMap); err != nil {
+	if err := m.updateMutatingWebhookConfiguration(getResourceMutatingWebhookConfigName(m.serverIP), webhooksMap, dedicated); err != nil {
 		logger.V(4).Info("failed to update mutatingwebhookconfigurations", "error", err.Error())
 		errs = append(errs, err.Error())
 	}
 
-	if err := m.updateValidatingWebhookConfiguration(getResourceValidatingWebhookConfigName(m.serverIP), webhooksMap); err != nil {
+	if err := m.updateValidatingWebhookConfiguration(getResourceValidatingWebhookConfigName(m.serverIP), webhooksMap, dedicated); err != nil {
 		logger.V(4).Info("failed to update validatingwebhookconfigurations", "error", err.Error())
 		errs = append(errs, err.Error())
 	}
@@ -442,7 +524,7 @@ func (m *webhookConfigManager) updateWebhookConfig(webhooks []*webhook) error {
 	return nil
 }
 
-func (m *webhookConfigManager) updateMutatingWebhookConfiguration(webhookName string, webhooksMap map[string]*webhook) error {
+func (m *webhookConfigManager) updateMutatingWebhookConfiguration(webhookName string, webhooksMap map[string]*webhook, dedicated []*webhook) error {
 	logger := m.log.WithName("updateMutatingWebhookConfiduration").WithValues("name", webhookName)
 	resourceWebhook, err := m.mutateLister.Get(webhookName)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -451,15 +533,47 @@ func (m *webhookConfigManager) updateMutatingWebhookConfiguration(webhookName st
 		m.createDefaultWebhook <- kindMutating
 		return err
 	}
+	resourceWebhook = resourceWebhook.DeepCopy()
+	var webhooks []admissionregistrationv1.MutatingWebhook
+	for _, w := range resourceWebhook.Webhooks {
+		if !isDedicatedWebhookName(config.MutatingWebhookName, w.Name) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	resourceWebhook.Webhooks = webhooks
 	for i := range resourceWebhook.Webhooks {
 		newWebhook := webhooksMap[webhookKey(kindMutating, string(*resourceWebhook.Webhooks[i].FailurePolicy))]
 		if newWebhook == nil || newWebhook.isEmpty() {
 			resourceWebhook.Webhooks[i].Rules = []admissionregistrationv1.RuleWithOperations{}
 		} else {
+			selectors := m.getSelectors(resourceWebhook.Webhooks[i].Name, newWebhook)
+			resourceWebhook.Webhooks[i].NamespaceSelector = selectors.namespaceSelector
+			resourceWebhook.Webhooks[i].ObjectSelector = selectors.objectSelector
 			resourceWebhook.Webhooks[i].TimeoutSeconds = &newWebhook.maxWebhookTimeout
-			resourceWebhook.Webhooks[i].Rules = []admissionregistrationv1.RuleWithOperations{
-				newWebhook.buildRuleWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete),
+			resourceWebhook.Webhooks[i].Rules = newWebhook.buildRulesWithOperations(mutatingWebhookOperations...)
+		}
+	}
+	var dedicatedErr error
+	if len(resourceWebhook.Webhooks) == 0 {
+		if keys := dedicatedPolicyKeys(dedicated, kindMutating); len(keys) > 0 {
+			dedicatedErr = errors.Errorf("unable to register the dedicated webhooks of policies %s: no webhook in %s to copy them from", strings.Join(keys, ", "), webhookName)
+			logger.Error(dedicatedErr, "skipping dedicated webhooks")
+		}
+	} else {
+		for _, w := range dedicated {
+			if w.kind != kindMutating || w.isEmpty() {
+				continue
 			}
+			template := resourceWebhook.Webhooks[0].DeepCopy()
+			template.Name = dedicatedWebhookName(config.MutatingWebhookName, w.policyKey)
+			selectors := m.getSelectors(template.Name, w)
+			template.ClientConfig = m.dedicatedClientConfig(template.ClientConfig, config.DedicatedMutatingWebhookServicePath, w.policyKey)
+			template.FailurePolicy = failurePolicyPtr(w.failurePolicy)
+			template.TimeoutSeconds = &w.maxWebhookTimeout
+			template.Rules = w.buildRulesWithOperations(mutatingWebhookOperations...)
+			template.NamespaceSelector = selectors.namespaceSelector
+			template.ObjectSelector = selectors.objectSelector
+			resourceWebhook.Webhooks = append(resourceWebhook.Webhooks, *template)
 		}
 	}
 	if _, err := m.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), resourceWebhook, metav1.UpdateOptions{}); err != nil {
@@ -467,10 +581,10 @@ func (m *webhookConfigManager) updateMutatingWebhookConfiguration(webhookName st
 		return errors.Wrapf(err, "unable to update: %s", resourceWebhook.GetName())
 	}
 	logger.V(4).Info("successfully updated the webhook configuration")
-	return nil
+	return dedicatedErr
 }
 
-func (m *webhookConfigManager) updateValidatingWebhookConfiguration(webhookName string, webhooksMap map[string]*webhook) error {
+func (m *webhookConfigManager) updateValidatingWebhookConfiguration(webhookName string, webhooksMap map[string]*webhook, dedicated []*webhook) error {
 	logger := m.log.WithName("updateMutatingWebhookConfiduration").WithValues("name", webhookName)
 	resourceWebhook, err := m.validateLister.Get(webhookName)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -479,15 +593,47 @@ func (m *webhookConfigManager) updateValidatingWebhookConfiguration(webhookName
 		m.createDefaultWebhook <- kindValidating
 		return err
 	}
+	resourceWebhook = resourceWebhook.DeepCopy()
+	var webhooks []admissionregistrationv1.ValidatingWebhook
+	for _, w := range resourceWebhook.Webhooks {
+		if !isDedicatedWebhookName(config.ValidatingWebhookName, w.Name) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	resourceWebhook.Webhooks = webhooks
 	for i := range resourceWebhook.Webhooks {
 		newWebhook := webhooksMap[webhookKey(kindValidating, string(*resourceWebhook.Webhooks[i].FailurePolicy))]
 		if newWebhook == nil || newWebhook.isEmpty() {
 			resourceWebhook.Webhooks[i].Rules = []admissionregistrationv1.RuleWithOperations{}
 		} else {
+			selectors := m.getSelectors(resourceWebhook.Webhooks[i].Name, newWebhook)
+			resourceWebhook.Webhooks[i].NamespaceSelector = selectors.namespaceSelector
+			resourceWebhook.Webhooks[i].ObjectSelector = selectors.objectSelector
 			resourceWebhook.Webhooks[i].TimeoutSeconds = &newWebhook.maxWebhookTimeout
-			resourceWebhook.Webhooks[i].Rules = []admissionregistrationv1.RuleWithOperations{
-				newWebhook.buildRuleWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
+			resourceWebhook.Webhooks[i].Rules = newWebhook.buildRulesWithOperations(validatingWebhookOperations...)
+		}
+	}
+	var dedicatedErr error
+	if len(resourceWebhook.Webhooks) == 0 {
+		if keys := dedicatedPolicyKeys(dedicated, kindValidating); len(keys) > 0 {
+			dedicatedErr = errors.Errorf("unable to register the dedicated webhooks of policies %s: no webhook in %s to copy them from", strings.Join(keys, ", "), webhookName)
+			logger.Error(dedicatedErr, "skipping dedicated webhooks")
+		}
+	} else {
+		for _, w := range dedicated {
+			if w.kind != kindValidating || w.isEmpty() {
+				continue
 			}
+			template := resourceWebhook.Webhooks[0].DeepCopy()
+			template.Name = dedicatedWebhookName(config.ValidatingWebhookName, w.policyKey)
+			selectors := m.getSelectors(template.Name, w)
+			template.ClientConfig = m.dedicatedClientConfig(template.ClientConfig, config.DedicatedValidatingWebhookServicePath, w.policyKey)
+			template.FailurePolicy = failurePolicyPtr(w.failurePolicy)
+			template.TimeoutSeconds = &w.maxWebhookTimeout
+			template.Rules = w.buildRulesWithOperations(validatingWebhookOperations...)
+			template.NamespaceSelector = selectors.namespaceSelector
+			template.ObjectSelector = selectors.objectSelector
+			resourceWebhook.Webhooks = append(resourceWebhook.Webhooks, *template)
 		}
 	}
 	if _, err := m.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), resourceWebhook, metav1.UpdateOptions{}); err != nil {
@@ -495,7 +641,7 @@ func (m *webhookConfigManager) updateValidatingWebhookConfiguration(webhookName
 		return errors.Wrapf(err, "unable to update: %s", resourceWebhook.GetName())
 	}
 	logger.V(4).Info("successfully updated the webhook configuration")
-	return nil
+	return dedicatedErr
 }
 
 func (m *webhookConfigManager) updateStatus(namespace, name string, ready bool) error {
@@ -545,34 +691,192 @@ type webhook struct {
 	kind              string
 	maxWebhookTimeout int32
 	failurePolicy     kyvernov1.FailurePolicyType
-	groups            sets.String
-	versions          sets.String
-	resources         sets.String
+	// policyKey is the key of the policy registered in a dedicated webhook, empty for the shared webhooks
+	policyKey string
+	// rules are keyed by the admission operations of the matched resources
+	rules map[string]*webhookRule
+	// namespaceSelector and objectSelector match the resources of any rule,
+	// hasSelectors is set once the selectors of a rule have been merged
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+	hasSelectors      bool
 }
 
-func (wh *webhook) buildRuleWithOperations(ops ...admissionregistrationv1.OperationType) admissionregistrationv1.RuleWithOperations {
-	return admissionregistrationv1.RuleWithOperations{
-		Rule: admissionregistrationv1.Rule{
-			APIGroups:   wh.groups.List(),
-			APIVersions: wh.versions.List(),
-			Resources:   wh.resources.List(),
-		},
-		Operations: ops,
+// webhookRule aggregates the GVR matched on the same admission operations,
+// an empty list of operations stands for all the operations of the webhook
+type webhookRule struct {
+	operations []kyvernov1.AdmissionOperation
+	groups     sets.String
+	versions   sets.String
+	resources  sets.String
+}
+
+// matchedKinds are the kinds matched by a rule on a list of admission operations, all operations if empty
+type matchedKinds struct {
+	kinds      []string
+	operations []kyvernov1.AdmissionOperation
+}
+
+func (r *webhookRule) isEmpty() bool {
+	return r.groups.Len() == 0 || r.versions.Len() == 0 || r.resources.Len() == 0
+}
+
+// the operations registered in the resource webhooks, restricted to the operations matched by the rules
+var (
+	mutatingWebhookOperations   = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete}
+	validatingWebhookOperations = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect}
+)
+
+// buildRulesWithOperations returns a rule per set of operations, the operations of the matched resources
+// are restricted to the operations supported by the webhook
+func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
+	keys := make([]string, 0, len(wh.rules))
+	for key := range wh.rules {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+
+	var rules []admissionregistrationv1.RuleWithOperations
+	for _, key := range keys {
+		rule := wh.rules[key]
+		if rule.isEmpty() {
+			continue
+		}
+
+		operations := ops
+		if len(rule.operations) > 0 {
+			operations = nil
+			for _, op := range ops {
+				for _, operation := range rule.operations {
+					if string(op) == string(operation) {
+						operations = append(operations, op)
+						break
+					}
+				}
+			}
+		}
+
+		if len(operations) == 0 {
+			continue
+		}
+
+		rules = append(rules, admissionregistrationv1.RuleWithOperations{
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   rule.groups.List(),
+				APIVersions: rule.versions.List(),
+				Resources:   rule.resources.List(),
+			},
+			Operations: operations,
+		})
+	}
+	return rules
 }
 
 func (wh *webhook) isEmpty() bool {
-	return wh.groups.Len() == 0 || wh.versions.Len() == 0 || wh.resources.Len() == 0
+	for _, rule := range wh.rules {
+		if !rule.isEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// rule returns the rule aggregating the resources matched on the operations
+func (wh *webhook) rule(operations []kyvernov1.AdmissionOperation) *webhookRule {
+	ops := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		ops = append(ops, string(operation))
+	}
+	ops = sets.NewString(ops...).List()
+	key := strings.Join(ops, ",")
+
+	rule, ok := wh.rules[key]
+	if !ok {
+		rule = &webhookRule{
+			groups:    sets.NewString(),
+			versions:  sets.NewString(),
+			resources: sets.NewString(),
+		}
+		for _, op := range ops {
+			rule.operations = append(rule.operations, kyvernov1.AdmissionOperation(op))
+		}
+		wh.rules[key] = rule
+	}
+	return rule
+}
+
+// getMatchedKinds returns the kinds matched by a rule, grouped by admission operations
+func getMatchedKinds(match kyvernov1.MatchResources) []matchedKinds {
+	matched := []matchedKinds{{kinds: match.Kinds, operations: match.Operations}}
+	for _, filter := range match.Any {
+		matched = append(matched, matchedKinds{kinds: filter.Kinds, operations: filter.Operations})
+	}
+
+	if len(match.All) > 0 {
+		// all the filters must match, so only the operations common to the filters are matched
+		var kinds []string
+		var operations sets.String
+		for _, filter := range match.All {
+			kinds = append(kinds, filter.Kinds...)
+			if len(filter.Operations) == 0 {
+				continue
+			}
+
+			ops := sets.NewString()
+			for _, operation := range filter.Operations {
+				ops.Insert(string(operation))
+			}
+			if operations == nil {
+				operations = ops
+			} else {
+				operations = operations.Intersection(ops)
+			}
+		}
+
+		if operations == nil || operations.Len() > 0 {
+			all := matchedKinds{kinds: kinds}
+			for _, op := range operations.List() {
+				all.operations = append(all.operations, kyvernov1.AdmissionOperation(op))
+			}
+			matched = append(matched, all)
+		}
+	}
+
+	return matched
 }
 
 // mergeWebhook merges the matching kinds of the policy to webhook.rule
 func (m *webhookConfigManager) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface, updateValidate bool) {
-	matchedGVK := make([]string, 0)
-	for _, rule := range autogen.ComputeRules(policy) {
+	m.mergeWebhookRules(dst, policy, autogen.ComputeRules(policy), updateValidate)
+
+	spec := policy.GetSpec()
+	if spec.WebhookTimeoutSeconds != nil {
+		if dst.maxWebhookTimeout < *spec.WebhookTimeoutSeconds {
+			dst.maxWebhookTimeout = *spec.WebhookTimeoutSeconds
+		}
+	}
+}
+
+// mergeWebhookRules merges the matching kinds of the rules of the policy to webhook.rule
+func (m *webhookConfigManager) mergeWebhookRules(dst *webhook, policy kyvernov1.PolicyInterface, rules []kyvernov1.Rule, updateValidate bool) {
+	var matched []matchedKinds
+	for _, rule := range rules {
 		// matching kinds in generate policies need to be added to both webhook
+		// on all operations, to sync and clean up the generated resources
 		if rule.HasGenerate() {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
-			matchedGVK = append(matchedGVK, rule.Generation.ResourceSpec.Kind)
+			kinds := rule.MatchResources.GetKinds()
+			if rule.Generation.ResourceSpec.Kind != "" {
+				kinds = append(kinds, rule.Generation.ResourceSpec.Kind)
+			}
+			for _, fe := range rule.Generation.ForEachGeneration {
+				if fe.Kind != "" {
+					kinds = append(kinds, fe.Kind)
+				}
+			}
+			// the sources and targets of a clone list are kept in sync on their updates
+			kinds = append(kinds, rule.Generation.CloneList.Kinds...)
+			matched = append(matched, matchedKinds{kinds: kinds})
+			dst.mergeSelectors(getRuleSelectors(policy.GetNamespace(), rule))
 			continue
 		}
 
@@ -580,13 +884,42 @@ func (m *webhookConfigManager) mergeWebhook(dst *webhook, policy kyvernov1.Polic
 			(updateValidate && rule.HasMutate() && rule.IsMutateExisting()) ||
 			(!updateValidate && rule.HasMutate()) && !rule.IsMutateExisting() ||
 			(!updateValidate && rule.HasVerifyImages()) || (!updateValidate && rule.HasYAMLSignatureVerify()) {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
+			matched = append(matched, getMatchedKinds(rule.MatchResources)...)
+			dst.mergeSelectors(getRuleSelectors(policy.GetNamespace(), rule))
+		}
+	}
+
+	for _, kinds := range matched {
+		if len(kinds.kinds) == 0 {
+			continue
+		}
+
+		dstRule := dst.rule(kinds.operations)
+		for _, gvr := range m.getGVRs(kinds.kinds) {
+			dstRule.groups.Insert(gvr.Group)
+			if gvr.Version == "*" {
+				dstRule.versions = sets.NewString()
+				dstRule.versions.Insert(gvr.Version)
+			} else if !dstRule.versions.Has("*") {
+				dstRule.versions.Insert(gvr.Version)
+			}
+			dstRule.resources.Insert(gvr.Resource)
+		}
+
+		if dstRule.resources.Has("pods") {
+			dstRule.resources.Insert("pods/ephemeralcontainers")
+		}
+		if dstRule.resources.Has("services") {
+			dstRule.resources.Insert("services/status")
 		}
 	}
+}
 
+// getGVRs converts the kinds matched by a policy to the resources registered in the webhook
+func (m *webhookConfigManager) getGVRs(kinds []string) []schema.GroupVersionResource {
 	gvkMap := make(map[string]int)
 	gvrList := make([]schema.GroupVersionResource, 0)
-	for _, gvk := range matchedGVK {
+	for _, gvk := range kinds {
 		if _, ok := gvkMap[gvk]; !ok {
 			gvkMap[gvk] = 1
 
@@ -622,31 +955,7 @@ func (m *webhookConfigManager) mergeWebhook(dst *webhook, policy kyvernov1.Polic
 			}
 		}
 	}
-
-	for _, gvr := range gvrList {
-		dst.groups.Insert(gvr.Group)
-		if gvr.Version == "*" {
-			dst.versions = sets.NewString()
-			dst.versions.Insert(gvr.Version)
-		} else if !dst.versions.Has("*") {
-			dst.versions.Insert(gvr.Version)
-		}
-		dst.resources.Insert(gvr.Resource)
-	}
-
-	if dst.resources.Has("pods") {
-		dst.resources.Insert("pods/ephemeralcontainers")
-	}
-	if dst.resources.Has("services") {
-		dst.resources.Insert("services/status")
-	}
-
-	spec := policy.GetSpec()
-	if spec.WebhookTimeoutSeconds != nil {
-		if dst.maxWebhookTimeout < *spec.WebhookTimeoutSeconds {
-			dst.maxWebhookTimeout = *spec.WebhookTimeoutSeconds
-		}
-	}
+	return gvrList
 }
 
 func newWebhook(kind string, timeout int32, failurePolicy kyvernov1.FailurePolicyType) *webhook {
@@ -654,9 +963,7 @@ func newWebhook(kind string, timeout int32, failurePolicy kyvernov1.FailurePolic
 		kind:              kind,
 		maxWebhookTimeout: timeout,
 		failurePolicy:     failurePolicy,
-		groups:            sets.NewString(),
-		versions:          sets.NewString(),
-		resources:         sets.NewString(),
+		rules:             map[string]*webhookRule{},
 	}
 }
 
@@ -674,7 +981,11 @@ func hasWildcard(spec *kyvernov1.Spec) bool {
 }
 
 func setWildcardConfig(w *webhook) {
-	w.groups = sets.NewString("*")
-	w.versions = sets.NewString("*")
-	w.resources = sets.NewString("*/*")
+	w.rules = map[string]*webhookRule{
+		"": {
+			groups:    sets.NewString("*"),
+			versions:  sets.NewString("*"),
+			resources: sets.NewString("*/*"),
+		},
+	}
 }
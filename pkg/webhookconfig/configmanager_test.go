@@ -3,10 +3,14 @@ package webhookconfig
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
 
+var cmpMatchedKinds = cmp.AllowUnexported(matchedKinds{})
+
 func Test_webhook_isEmpty(t *testing.T) {
 	empty := newWebhook(kindMutating, DefaultWebhookTimeout, kyverno.Ignore)
 	assert.Equal(t, empty.isEmpty(), true)
@@ -14,3 +18,69 @@ func Test_webhook_isEmpty(t *testing.T) {
 	setWildcardConfig(notEmpty)
 	assert.Equal(t, notEmpty.isEmpty(), false)
 }
+
+func Test_getMatchedKinds(t *testing.T) {
+	matched := getMatchedKinds(kyverno.MatchResources{
+		Any: kyverno.ResourceFilters{
+			{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}},
+			{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Service"}}},
+		},
+	})
+	assert.DeepEqual(t, matched, []matchedKinds{
+		{},
+		{kinds: []string{"Pod"}, operations: []kyverno.AdmissionOperation{kyverno.Create}},
+		{kinds: []string{"Service"}},
+	}, cmpMatchedKinds)
+
+	// only the operations common to all the filters are matched
+	matched = getMatchedKinds(kyverno.MatchResources{
+		All: kyverno.ResourceFilters{
+			{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create, kyverno.Update}}},
+			{ResourceDescription: kyverno.ResourceDescription{Operations: []kyverno.AdmissionOperation{kyverno.Update, kyverno.Delete}}},
+			{ResourceDescription: kyverno.ResourceDescription{Namespaces: []string{"prod"}}},
+		},
+	})
+	assert.DeepEqual(t, matched, []matchedKinds{
+		{},
+		{kinds: []string{"Pod"}, operations: []kyverno.AdmissionOperation{kyverno.Update}},
+	}, cmpMatchedKinds)
+
+	matched = getMatchedKinds(kyverno.MatchResources{
+		All: kyverno.ResourceFilters{
+			{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}},
+			{ResourceDescription: kyverno.ResourceDescription{Operations: []kyverno.AdmissionOperation{kyverno.Delete}}},
+		},
+	})
+	assert.Equal(t, len(matched), 1)
+}
+
+func Test_webhook_buildRulesWithOperations(t *testing.T) {
+	wh := newWebhook(kindMutating, DefaultWebhookTimeout, kyverno.Ignore)
+	all := wh.rule(nil)
+	all.groups.Insert("")
+	all.versions.Insert("v1")
+	all.resources.Insert("services")
+
+	create := wh.rule([]kyverno.AdmissionOperation{kyverno.Create, kyverno.Connect})
+	create.groups.Insert("")
+	create.versions.Insert("v1")
+	create.resources.Insert("pods")
+
+	// CONNECT is not supported by the mutating webhook
+	connect := wh.rule([]kyverno.AdmissionOperation{kyverno.Connect})
+	connect.groups.Insert("")
+	connect.versions.Insert("v1")
+	connect.resources.Insert("pods/exec")
+
+	assert.Assert(t, wh.rule([]kyverno.AdmissionOperation{kyverno.Connect, kyverno.Create}) == create)
+	assert.DeepEqual(t, wh.buildRulesWithOperations(mutatingWebhookOperations...), []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete},
+			Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"services"}},
+		},
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+		},
+	})
+}
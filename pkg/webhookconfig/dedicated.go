@@ -0,0 +1,130 @@
+package webhookconfig
+
+import (
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// buildDedicatedWebhooks returns the webhooks dedicated to a policy, configured with the failure policy
+// and the timeout of the policy. The validating webhook only registers the validation checks of enforced
+// policies, the other rules are processed in the background by the shared validating webhook.
+func (m *webhookConfigManager) buildDedicatedWebhooks(policy kyvernov1.PolicyInterface) []*webhook {
+	key, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		m.log.Error(err, "unable to compute the key of the policy", "namespace", policy.GetNamespace(), "name", policy.GetName())
+		return nil
+	}
+
+	spec := policy.GetSpec()
+	timeout := DefaultWebhookTimeout
+	if spec.WebhookTimeoutSeconds != nil {
+		timeout = *spec.WebhookTimeoutSeconds
+	}
+
+	var webhooks []*webhook
+	if spec.HasMutate() || spec.HasVerifyImages() {
+		mutate := newWebhook(kindMutating, timeout, spec.GetFailurePolicy())
+		mutate.policyKey = key
+		if hasWildcard(spec) {
+			setWildcardConfig(mutate)
+		} else {
+			m.mergeWebhookRules(mutate, policy, autogen.ComputeRules(policy), false)
+		}
+		webhooks = append(webhooks, mutate)
+	}
+
+	var rules []kyvernov1.Rule
+	enforce := isEnforcePolicy(spec)
+	for _, rule := range autogen.ComputeRules(policy) {
+		if (rule.HasValidate() && enforce) || rule.HasImagesValidationChecks() {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) > 0 {
+		validate := newWebhook(kindValidating, timeout, spec.GetFailurePolicy())
+		validate.policyKey = key
+		if hasWildcard(spec) {
+			setWildcardConfig(validate)
+		} else {
+			m.mergeWebhookRules(validate, policy, rules, true)
+		}
+		webhooks = append(webhooks, validate)
+	}
+	return webhooks
+}
+
+// backgroundRules returns the rules of a policy using a dedicated webhook that are processed
+// in the background by the shared validating webhook
+func backgroundRules(policy kyvernov1.PolicyInterface) []kyvernov1.Rule {
+	var rules []kyvernov1.Rule
+	enforce := isEnforcePolicy(policy.GetSpec())
+	for _, rule := range autogen.ComputeRules(policy) {
+		if rule.HasGenerate() || (rule.HasMutate() && rule.IsMutateExisting()) || (rule.HasValidate() && !enforce) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// isEnforcePolicy checks if the validation checks of a policy are performed in the admission request,
+// the same way the policy cache does
+func isEnforcePolicy(spec *kyvernov1.Spec) bool {
+	if spec.GetValidationFailureAction() == kyvernov1.Enforce {
+		return true
+	}
+	for _, override := range spec.ValidationFailureActionOverrides {
+		if override.Action == kyvernov1.Enforce {
+			return true
+		}
+	}
+	return false
+}
+
+// dedicatedWebhookName returns the name of the webhook dedicated to a policy, cluster policies and
+// policies are prefixed differently as policy names can contain dots
+func dedicatedWebhookName(webhookName, policyKey string) string {
+	namespace, name, _ := cache.SplitMetaNamespaceKey(policyKey)
+	if namespace == "" {
+		return fmt.Sprintf("%s-cpol-%s", webhookName, name)
+	}
+	return fmt.Sprintf("%s-pol-%s.%s", webhookName, namespace, name)
+}
+
+func isDedicatedWebhookName(webhookName, name string) bool {
+	return strings.HasPrefix(name, webhookName+"-cpol-") || strings.HasPrefix(name, webhookName+"-pol-")
+}
+
+// dedicatedClientConfig returns the client config of the shared webhook, pointing to the path serving the policy
+func (m *webhookConfigManager) dedicatedClientConfig(clientConfig admissionregistrationv1.WebhookClientConfig, servicePath, policyKey string) admissionregistrationv1.WebhookClientConfig {
+	path := servicePath + "/" + policyKey
+	if clientConfig.Service != nil {
+		service := *clientConfig.Service
+		service.Path = &path
+		clientConfig.Service = &service
+	} else {
+		url := fmt.Sprintf("https://%s%s", m.serverIP, path)
+		clientConfig.URL = &url
+	}
+	return clientConfig
+}
+
+// dedicatedPolicyKeys returns the keys of the policies with a dedicated webhook of a kind
+func dedicatedPolicyKeys(dedicated []*webhook, kind string) []string {
+	var keys []string
+	for _, w := range dedicated {
+		if w.kind == kind && !w.isEmpty() {
+			keys = append(keys, w.policyKey)
+		}
+	}
+	return keys
+}
+
+func failurePolicyPtr(failurePolicy kyvernov1.FailurePolicyType) *admissionregistrationv1.FailurePolicyType {
+	policy := admissionregistrationv1.FailurePolicyType(failurePolicy)
+	return &policy
+}
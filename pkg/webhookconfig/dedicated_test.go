@@ -0,0 +1,107 @@
+package webhookconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	admissionregistrationv1listers "k8s.io/client-go/listers/admissionregistration/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_dedicatedWebhookName(t *testing.T) {
+	assert.Equal(t, dedicatedWebhookName("mutate.kyverno.svc", "require-labels"), "mutate.kyverno.svc-cpol-require-labels")
+	assert.Equal(t, dedicatedWebhookName("mutate.kyverno.svc", "prod/require-labels"), "mutate.kyverno.svc-pol-prod.require-labels")
+	assert.Assert(t, isDedicatedWebhookName("mutate.kyverno.svc", "mutate.kyverno.svc-pol-prod.require-labels"))
+	assert.Assert(t, !isDedicatedWebhookName("mutate.kyverno.svc", "mutate.kyverno.svc-fail"))
+	assert.Assert(t, !isDedicatedWebhookName("mutate.kyverno.svc", "validate.kyverno.svc-cpol-require-labels"))
+}
+
+func Test_dedicatedClientConfig(t *testing.T) {
+	m := &webhookConfigManager{serverIP: "10.0.0.1"}
+	path := "/mutate"
+	clientConfig := m.dedicatedClientConfig(admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{Namespace: "kyverno", Name: "kyverno-svc", Path: &path},
+	}, "/mutate/policy", "prod/require-labels")
+	assert.Equal(t, *clientConfig.Service.Path, "/mutate/policy/prod/require-labels")
+	assert.Equal(t, clientConfig.Service.Name, "kyverno-svc")
+	assert.Equal(t, path, "/mutate")
+
+	url := "https://10.0.0.1/mutate"
+	clientConfig = m.dedicatedClientConfig(admissionregistrationv1.WebhookClientConfig{URL: &url}, "/mutate/policy", "require-labels")
+	assert.Equal(t, *clientConfig.URL, "https://10.0.0.1/mutate/policy/require-labels")
+}
+
+func Test_backgroundRules(t *testing.T) {
+	policy := &kyverno.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec: kyverno.Spec{
+			ValidationFailureAction: kyverno.Enforce,
+			Rules: []kyverno.Rule{
+				{Name: "validate", Validation: kyverno.Validation{Message: "validate"}},
+				{Name: "generate", Generation: kyverno.Generation{ResourceSpec: kyverno.ResourceSpec{Kind: "ConfigMap"}}},
+			},
+		},
+	}
+	rules := backgroundRules(policy)
+	assert.Equal(t, len(rules), 1)
+	assert.Equal(t, rules[0].Name, "generate")
+
+	// audited validation checks are reported in the background
+	policy.Spec.ValidationFailureAction = kyverno.Audit
+	assert.Equal(t, len(backgroundRules(policy)), 2)
+}
+
+func newMutatingWebhookManager(t *testing.T, configuration *admissionregistrationv1.MutatingWebhookConfiguration) *webhookConfigManager {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, indexer.Add(configuration))
+	return &webhookConfigManager{
+		kubeClient:   fake.NewSimpleClientset(configuration),
+		mutateLister: admissionregistrationv1listers.NewMutatingWebhookConfigurationLister(indexer),
+		log:          logr.Discard(),
+	}
+}
+
+func Test_updateMutatingWebhookConfiguration_Dedicated(t *testing.T) {
+	ignore := admissionregistrationv1.Ignore
+	configuration := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: config.MutatingWebhookConfigurationName},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: config.MutatingWebhookName + "-ignore", FailurePolicy: &ignore},
+		},
+	}
+
+	// the dedicated webhook registers the operations matched by the policy
+	dedicated := newWebhook(kindMutating, DefaultWebhookTimeout, kyverno.Fail)
+	dedicated.policyKey = "cleanup-pods"
+	rule := dedicated.rule([]kyverno.AdmissionOperation{kyverno.Delete})
+	rule.groups.Insert("")
+	rule.versions.Insert("v1")
+	rule.resources.Insert("pods")
+
+	m := newMutatingWebhookManager(t, configuration)
+	assert.NilError(t, m.updateMutatingWebhookConfiguration(configuration.Name, map[string]*webhook{}, []*webhook{dedicated}))
+
+	updated, err := m.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), configuration.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.Webhooks), 2)
+	assert.Equal(t, updated.Webhooks[1].Name, config.MutatingWebhookName+"-cpol-cleanup-pods")
+	assert.DeepEqual(t, updated.Webhooks[1].Rules, []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Delete},
+			Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+		},
+	})
+
+	// the dedicated webhooks are copied from the shared webhooks, they can not be registered without them
+	configuration.Webhooks = nil
+	m = newMutatingWebhookManager(t, configuration)
+	err = m.updateMutatingWebhookConfiguration(configuration.Name, map[string]*webhook{}, []*webhook{dedicated})
+	assert.ErrorContains(t, err, "unable to register the dedicated webhooks of policies cleanup-pods")
+}
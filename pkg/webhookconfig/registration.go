@@ -268,7 +268,8 @@ func (wrc *Register) UpdateWebhooksCaBundle() error {
 // UpdateWebhookConfigurations updates resource webhook configurations dynamically
 // based on the UPDATEs of Kyverno ConfigMap defined in INIT_CONFIG env
 //
-// it currently updates namespaceSelector only, can be extend to update other fields
+// the namespaceSelector and objectSelector of the ConfigMap are combined with
+// the selectors of the policies registered in each webhook
 // +deprecated
 func (wrc *Register) UpdateWebhookConfigurations(configHandler config.Configuration) {
 	logger := wrc.log.WithName("UpdateWebhookConfigurations")
@@ -282,18 +283,7 @@ func (wrc *Register) UpdateWebhookConfigurations(configHandler config.Configurat
 			webhookCfg = webhookCfgs[0]
 		}
 
-		retry := false
-		if err := wrc.updateResourceMutatingWebhookConfiguration(webhookCfg); err != nil {
-			logger.Error(err, "unable to update mutatingWebhookConfigurations", "name", getResourceMutatingWebhookConfigName(wrc.serverIP))
-			retry = true
-		}
-
-		if err := wrc.updateResourceValidatingWebhookConfiguration(webhookCfg); err != nil {
-			logger.Error(err, "unable to update validatingWebhookConfigurations", "name", getResourceValidatingWebhookConfigName(wrc.serverIP))
-			retry = true
-		}
-
-		if retry {
+		if !wrc.updateResourceWebhookSelectors(webhookCfg) {
 			go func() {
 				time.Sleep(1 * time.Second)
 				select {
@@ -307,6 +297,25 @@ func (wrc *Register) UpdateWebhookConfigurations(configHandler config.Configurat
 	}
 }
 
+// updateResourceWebhookSelectors sets the selectors of the Kyverno ConfigMap on the resource webhook configurations,
+// it returns false if one of the configurations could not be updated
+func (wrc *Register) updateResourceWebhookSelectors(webhookCfg config.WebhookConfig) bool {
+	logger := wrc.log.WithName("updateResourceWebhookSelectors")
+	wrc.manage.updateConfigSelectors(webhookCfg)
+
+	updated := true
+	if err := wrc.updateResourceMutatingWebhookConfiguration(); err != nil {
+		logger.Error(err, "unable to update mutatingWebhookConfigurations", "name", getResourceMutatingWebhookConfigName(wrc.serverIP))
+		updated = false
+	}
+
+	if err := wrc.updateResourceValidatingWebhookConfiguration(); err != nil {
+		logger.Error(err, "unable to update validatingWebhookConfigurations", "name", getResourceValidatingWebhookConfigName(wrc.serverIP))
+		updated = false
+	}
+	return updated
+}
+
 func (wrc *Register) ValidateWebhookConfigurations(namespace, name string) error {
 	logger := wrc.log.WithName("ValidateWebhookConfigurations")
 	cm, err := wrc.kubeClient.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
@@ -447,15 +456,16 @@ func (wrc *Register) checkEndpoint() error {
 	return err
 }
 
-func (wrc *Register) updateResourceValidatingWebhookConfiguration(webhookCfg config.WebhookConfig) error {
+func (wrc *Register) updateResourceValidatingWebhookConfiguration() error {
 	resource, err := wrc.vwcLister.Get(getResourceValidatingWebhookConfigName(wrc.serverIP))
 	if err != nil {
 		return errors.Wrapf(err, "unable to get validatingWebhookConfigurations")
 	}
 	copy := resource.DeepCopy()
 	for i := range copy.Webhooks {
-		copy.Webhooks[i].ObjectSelector = webhookCfg.ObjectSelector
-		copy.Webhooks[i].NamespaceSelector = webhookCfg.NamespaceSelector
+		selectors := wrc.manage.webhookSelectors(copy.Webhooks[i].Name)
+		copy.Webhooks[i].ObjectSelector = selectors.objectSelector
+		copy.Webhooks[i].NamespaceSelector = selectors.namespaceSelector
 	}
 	if reflect.DeepEqual(resource.Webhooks, copy.Webhooks) {
 		wrc.log.V(4).Info("namespaceSelector unchanged, skip updating validatingWebhookConfigurations")
@@ -465,19 +475,20 @@ func (wrc *Register) updateResourceValidatingWebhookConfiguration(webhookCfg con
 	if _, err := wrc.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), copy, metav1.UpdateOptions{}); err != nil {
 		return err
 	}
-	wrc.log.V(3).Info("successfully updated validatingWebhookConfigurations", "name", getResourceMutatingWebhookConfigName(wrc.serverIP))
+	wrc.log.V(3).Info("successfully updated validatingWebhookConfigurations", "name", getResourceValidatingWebhookConfigName(wrc.serverIP))
 	return nil
 }
 
-func (wrc *Register) updateResourceMutatingWebhookConfiguration(webhookCfg config.WebhookConfig) error {
+func (wrc *Register) updateResourceMutatingWebhookConfiguration() error {
 	resource, err := wrc.mwcLister.Get(getResourceMutatingWebhookConfigName(wrc.serverIP))
 	if err != nil {
 		return errors.Wrapf(err, "unable to get mutatingWebhookConfigurations")
 	}
 	copy := resource.DeepCopy()
 	for i := range copy.Webhooks {
-		copy.Webhooks[i].ObjectSelector = webhookCfg.ObjectSelector
-		copy.Webhooks[i].NamespaceSelector = webhookCfg.NamespaceSelector
+		selectors := wrc.manage.webhookSelectors(copy.Webhooks[i].Name)
+		copy.Webhooks[i].ObjectSelector = selectors.objectSelector
+		copy.Webhooks[i].NamespaceSelector = selectors.namespaceSelector
 	}
 	if reflect.DeepEqual(resource.Webhooks, copy.Webhooks) {
 		wrc.log.V(4).Info("namespaceSelector unchanged, skip updating mutatingWebhookConfigurations")
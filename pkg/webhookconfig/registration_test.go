@@ -0,0 +1,109 @@
+package webhookconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	admissionregistrationv1listers "k8s.io/client-go/listers/admissionregistration/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newResourceWebhookRegister(t *testing.T, m *webhookConfigManager) *Register {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: config.MutatingWebhookConfigurationName},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: config.MutatingWebhookName + "-ignore"},
+			{Name: config.MutatingWebhookName + "-fail"},
+		},
+	}
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: config.ValidatingWebhookConfigurationName},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{Name: config.ValidatingWebhookName + "-ignore"},
+			{Name: config.ValidatingWebhookName + "-fail"},
+		},
+	}
+	mwcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, mwcIndexer.Add(mutating))
+	vwcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, vwcIndexer.Add(validating))
+
+	kubeClient := fake.NewSimpleClientset(mutating, validating)
+	return &Register{
+		kubeClient:    kubeClient,
+		mwcLister:     admissionregistrationv1listers.NewMutatingWebhookConfigurationLister(mwcIndexer),
+		vwcLister:     admissionregistrationv1listers.NewValidatingWebhookConfigurationLister(vwcIndexer),
+		metricsConfig: metrics.NewFakeMetricsConfig(kubeClient),
+		log:           logr.Discard(),
+		manage:        m,
+	}
+}
+
+func Test_updateResourceWebhookSelectors(t *testing.T) {
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "prod"}}
+	objectSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+	webhookCfg := config.WebhookConfig{NamespaceSelector: namespaceSelector, ObjectSelector: objectSelector}
+
+	assertSelectors := func(t *testing.T, wrc *Register, policyObjectSelector *metav1.LabelSelector) {
+		mutating, err := wrc.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), config.MutatingWebhookConfigurationName, metav1.GetOptions{})
+		assert.NilError(t, err)
+		for _, w := range mutating.Webhooks {
+			assert.DeepEqual(t, w.NamespaceSelector, namespaceSelector)
+			if w.Name == config.MutatingWebhookName+"-fail" && policyObjectSelector != nil {
+				assert.DeepEqual(t, w.ObjectSelector, andSelectors(objectSelector, policyObjectSelector))
+			} else {
+				assert.DeepEqual(t, w.ObjectSelector, objectSelector)
+			}
+		}
+		validating, err := wrc.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), config.ValidatingWebhookConfigurationName, metav1.GetOptions{})
+		assert.NilError(t, err)
+		for _, w := range validating.Webhooks {
+			assert.DeepEqual(t, w.NamespaceSelector, namespaceSelector)
+			assert.DeepEqual(t, w.ObjectSelector, objectSelector)
+		}
+	}
+
+	t.Run("auto update disabled", func(t *testing.T) {
+		wrc := newResourceWebhookRegister(t, &webhookConfigManager{log: logr.Discard()})
+		assert.Assert(t, wrc.updateResourceWebhookSelectors(webhookCfg))
+		assertSelectors(t, wrc, nil)
+	})
+
+	t.Run("auto update enabled without policies", func(t *testing.T) {
+		m := &webhookConfigManager{
+			pLister:            kyvernov1listers.NewClusterPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			npLister:           kyvernov1listers.NewPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+			queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+			autoUpdateWebhooks: true,
+			log:                logr.Discard(),
+		}
+		wrc := newResourceWebhookRegister(t, m)
+		assert.Assert(t, wrc.updateResourceWebhookSelectors(webhookCfg))
+		assert.Equal(t, m.queue.Len(), 0)
+		assertSelectors(t, wrc, nil)
+	})
+
+	t.Run("selectors of the policies of a webhook", func(t *testing.T) {
+		policyObjectSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		m := &webhookConfigManager{log: logr.Discard()}
+		m.getSelectors(config.MutatingWebhookName+"-fail", &webhook{objectSelector: policyObjectSelector})
+		wrc := newResourceWebhookRegister(t, m)
+		assert.Assert(t, wrc.updateResourceWebhookSelectors(webhookCfg))
+		assertSelectors(t, wrc, policyObjectSelector)
+	})
+
+	t.Run("missing webhook configuration", func(t *testing.T) {
+		wrc := newResourceWebhookRegister(t, &webhookConfigManager{log: logr.Discard()})
+		wrc.serverIP = "10.0.0.1"
+		assert.Assert(t, !wrc.updateResourceWebhookSelectors(webhookCfg))
+	})
+}
@@ -0,0 +1,278 @@
+package webhookconfig
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	stringutils "github.com/kyverno/kyverno/pkg/utils/string"
+	wildcard "github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// namespaceNameLabel is set by the API server on every namespace to the name of the namespace
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// webhookSelectors are the namespace and object selectors of the resources matched by a rule,
+// a nil selector matches all the resources
+type webhookSelectors struct {
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+}
+
+// getRuleSelectors returns the selectors of the resources matched by a rule of a policy in the namespace,
+// the selectors are only set when the API server can evaluate them the same way the engine does
+func getRuleSelectors(namespace string, rule kyvernov1.Rule) webhookSelectors {
+	// generate rules need to receive the requests of the generated resources
+	if rule.HasGenerate() {
+		return webhookSelectors{}
+	}
+
+	var selectors webhookSelectors
+	match := rule.MatchResources
+	if len(match.Any) > 0 {
+		for i, filter := range match.Any {
+			filterSelectors := getFilterSelectors(filter.ResourceDescription)
+			if i == 0 {
+				selectors = filterSelectors
+			} else {
+				selectors.namespaceSelector = orSelectors(selectors.namespaceSelector, filterSelectors.namespaceSelector)
+				selectors.objectSelector = orSelectors(selectors.objectSelector, filterSelectors.objectSelector)
+			}
+		}
+	} else if len(match.All) > 0 {
+		for _, filter := range match.All {
+			filterSelectors := getFilterSelectors(filter.ResourceDescription)
+			selectors.namespaceSelector = andSelectors(selectors.namespaceSelector, filterSelectors.namespaceSelector)
+			selectors.objectSelector = andSelectors(selectors.objectSelector, filterSelectors.objectSelector)
+		}
+	} else {
+		selectors = getFilterSelectors(match.ResourceDescription)
+	}
+
+	// namespaced policies only apply to the resources of their namespace
+	if namespace != "" {
+		selectors.namespaceSelector = andSelectors(selectors.namespaceSelector, namespacesSelector([]string{namespace}))
+	}
+	return selectors
+}
+
+// getFilterSelectors returns the selectors of the resources matched by a resource description
+func getFilterSelectors(desc kyvernov1.ResourceDescription) webhookSelectors {
+	var selectors webhookSelectors
+	if isPropagable(desc.Selector) {
+		selectors.objectSelector = desc.Selector
+	}
+
+	if !stringutils.ContainsWildcard(strings.Join(desc.Namespaces, "")) {
+		selectors.namespaceSelector = namespacesSelector(desc.Namespaces)
+	}
+
+	// the API server evaluates the namespace selector against the labels of a namespace,
+	// whereas the engine ignores the namespace selector when the resource is a namespace
+	if isPropagable(desc.NamespaceSelector) && !matchesNamespaceKind(desc.Kinds) {
+		selectors.namespaceSelector = andSelectors(selectors.namespaceSelector, desc.NamespaceSelector)
+	}
+	return selectors
+}
+
+// namespacesSelector returns the selector matching the namespaces by name, nil if no namespace is given
+func namespacesSelector(namespaces []string) *metav1.LabelSelector {
+	if len(namespaces) == 0 {
+		return nil
+	}
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      namespaceNameLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   sets.NewString(namespaces...).List(),
+		}},
+	}
+}
+
+func matchesNamespaceKind(kinds []string) bool {
+	for _, gvk := range kinds {
+		if _, k := kubeutils.GetKindFromGVK(gvk); wildcard.Match(k, "Namespace") {
+			return true
+		}
+	}
+	return false
+}
+
+// isPropagable checks if a selector can be set in a webhook, the engine expands wildcards and variables
+// in selectors at admission time which the API server doesn't support
+func isPropagable(selector *metav1.LabelSelector) bool {
+	if selector == nil {
+		return false
+	}
+	isLiteral := func(s string) bool {
+		return !stringutils.ContainsWildcard(s) && !strings.Contains(s, "{{")
+	}
+	for k, v := range selector.MatchLabels {
+		if !isLiteral(k) || !isLiteral(v) {
+			return false
+		}
+	}
+	for _, expression := range selector.MatchExpressions {
+		if !isLiteral(expression.Key) {
+			return false
+		}
+		for _, v := range expression.Values {
+			if !isLiteral(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mergeSelectors aggregates the selectors of a rule registered in the webhook, the webhook
+// needs to receive the requests matched by any of its rules
+func (wh *webhook) mergeSelectors(selectors webhookSelectors) {
+	if !wh.hasSelectors {
+		wh.namespaceSelector = selectors.namespaceSelector
+		wh.objectSelector = selectors.objectSelector
+		wh.hasSelectors = true
+		return
+	}
+	wh.namespaceSelector = orSelectors(wh.namespaceSelector, selectors.namespaceSelector)
+	wh.objectSelector = orSelectors(wh.objectSelector, selectors.objectSelector)
+}
+
+// andSelectors returns a selector matching the labels matched by both selectors
+func andSelectors(a, b *metav1.LabelSelector) *metav1.LabelSelector {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return toSelector(append(toRequirements(a), toRequirements(b)...))
+}
+
+// orSelectors returns a selector matching the labels matched by either selector. As a label selector
+// can't express a union in general, the selectors are only combined when the result matches
+// exactly the same labels, otherwise nil is returned to match all the labels.
+func orSelectors(a, b *metav1.LabelSelector) *metav1.LabelSelector {
+	reqsA, reqsB := toRequirements(a), toRequirements(b)
+	if len(reqsA) == 0 || len(reqsB) == 0 {
+		return nil
+	}
+
+	// a selector with a subset of the requirements of the other selector matches more labels
+	if containsRequirements(reqsB, reqsA) {
+		return toSelector(reqsA)
+	}
+	if containsRequirements(reqsA, reqsB) {
+		return toSelector(reqsB)
+	}
+
+	// selectors differing by the values of a single requirement of the same key are combined,
+	// by merging the values of the In operators or keeping the common values of the NotIn operators
+	var common []metav1.LabelSelectorRequirement
+	var diffA, diffB []metav1.LabelSelectorRequirement
+	for _, req := range reqsA {
+		if containsRequirements(reqsB, []metav1.LabelSelectorRequirement{req}) {
+			common = append(common, req)
+		} else {
+			diffA = append(diffA, req)
+		}
+	}
+	for _, req := range reqsB {
+		if !containsRequirements(reqsA, []metav1.LabelSelectorRequirement{req}) {
+			diffB = append(diffB, req)
+		}
+	}
+	if len(diffA) != 1 || len(diffB) != 1 || diffA[0].Key != diffB[0].Key || diffA[0].Operator != diffB[0].Operator {
+		return nil
+	}
+
+	valuesA, valuesB := sets.NewString(diffA[0].Values...), sets.NewString(diffB[0].Values...)
+	var values sets.String
+	switch diffA[0].Operator {
+	case metav1.LabelSelectorOpIn:
+		values = valuesA.Union(valuesB)
+	case metav1.LabelSelectorOpNotIn:
+		values = valuesA.Intersection(valuesB)
+	default:
+		return nil
+	}
+
+	if values.Len() > 0 {
+		common = append(common, metav1.LabelSelectorRequirement{Key: diffA[0].Key, Operator: diffA[0].Operator, Values: values.List()})
+	}
+	if len(common) == 0 {
+		return nil
+	}
+	return toSelector(common)
+}
+
+// toRequirements converts a selector to sorted requirements, the match labels are converted to In requirements
+func toRequirements(selector *metav1.LabelSelector) []metav1.LabelSelectorRequirement {
+	if selector == nil {
+		return nil
+	}
+
+	var reqs []metav1.LabelSelectorRequirement
+	for k, v := range selector.MatchLabels {
+		reqs = append(reqs, metav1.LabelSelectorRequirement{Key: k, Operator: metav1.LabelSelectorOpIn, Values: []string{v}})
+	}
+	for _, expression := range selector.MatchExpressions {
+		req := *expression.DeepCopy()
+		if len(req.Values) > 0 {
+			req.Values = sets.NewString(req.Values...).List()
+		}
+		reqs = append(reqs, req)
+	}
+	return sortRequirements(reqs)
+}
+
+// toSelector converts requirements to a selector, nil if there is no requirement
+func toSelector(reqs []metav1.LabelSelectorRequirement) *metav1.LabelSelector {
+	reqs = sortRequirements(reqs)
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	selector := &metav1.LabelSelector{}
+	for i, req := range reqs {
+		if i > 0 && reflect.DeepEqual(req, reqs[i-1]) {
+			continue
+		}
+		selector.MatchExpressions = append(selector.MatchExpressions, req)
+	}
+	return selector
+}
+
+// containsRequirements checks if all the requirements of sub are found in reqs
+func containsRequirements(reqs, sub []metav1.LabelSelectorRequirement) bool {
+	for _, s := range sub {
+		found := false
+		for _, req := range reqs {
+			if reflect.DeepEqual(req, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sortRequirements(reqs []metav1.LabelSelectorRequirement) []metav1.LabelSelectorRequirement {
+	sort.SliceStable(reqs, func(i, j int) bool {
+		if reqs[i].Key != reqs[j].Key {
+			return reqs[i].Key < reqs[j].Key
+		}
+		if reqs[i].Operator != reqs[j].Operator {
+			return reqs[i].Operator < reqs[j].Operator
+		}
+		return strings.Join(reqs[i].Values, ",") < strings.Join(reqs[j].Values, ",")
+	})
+	return reqs
+}
@@ -0,0 +1,91 @@
+package webhookconfig
+
+import (
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_getRuleSelectors(t *testing.T) {
+	envSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+
+	selectors := getRuleSelectors("", kyverno.Rule{
+		MatchResources: kyverno.MatchResources{
+			ResourceDescription: kyverno.ResourceDescription{
+				Kinds:             []string{"Pod"},
+				Namespaces:        []string{"prod", "dev"},
+				NamespaceSelector: envSelector,
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			},
+		},
+	})
+	assert.DeepEqual(t, selectors.namespaceSelector, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+			{Key: namespaceNameLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"dev", "prod"}},
+		},
+	})
+	assert.DeepEqual(t, selectors.objectSelector, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}})
+
+	// the engine ignores the namespace selector for namespaces, and expands wildcards
+	selectors = getRuleSelectors("", kyverno.Rule{
+		MatchResources: kyverno.MatchResources{
+			Any: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Namespace"}, NamespaceSelector: envSelector}},
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"prod-*"}}},
+			},
+		},
+	})
+	assert.Assert(t, selectors.namespaceSelector == nil)
+	assert.Assert(t, selectors.objectSelector == nil)
+
+	// namespaced policies only match the resources of their namespace
+	selectors = getRuleSelectors("prod", kyverno.Rule{
+		MatchResources: kyverno.MatchResources{
+			All: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}},
+				{ResourceDescription: kyverno.ResourceDescription{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "*"}}}},
+			},
+		},
+	})
+	assert.DeepEqual(t, selectors.namespaceSelector, namespacesSelector([]string{"prod"}))
+	assert.Assert(t, selectors.objectSelector == nil)
+
+	// generate rules need to receive the requests of all the resources
+	selectors = getRuleSelectors("prod", kyverno.Rule{
+		MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Namespace"}}},
+		Generation:     kyverno.Generation{ResourceSpec: kyverno.ResourceSpec{Kind: "ConfigMap"}},
+	})
+	assert.Assert(t, selectors.namespaceSelector == nil)
+}
+
+func Test_orSelectors(t *testing.T) {
+	in := func(key string, values ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: key, Operator: metav1.LabelSelectorOpIn, Values: values}}}
+	}
+	assert.Assert(t, orSelectors(nil, in("env", "prod")) == nil)
+	assert.DeepEqual(t, orSelectors(in(namespaceNameLabel, "prod"), in(namespaceNameLabel, "dev")), in(namespaceNameLabel, "dev", "prod"))
+	assert.DeepEqual(t, orSelectors(&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod", "team": "a"}}, in("env", "prod")), in("env", "prod"))
+	assert.DeepEqual(t, orSelectors(
+		&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod", "team": "a"}},
+		&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod", "team": "b"}},
+	), &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+		{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+	}})
+	// a union of different keys can't be expressed
+	assert.Assert(t, orSelectors(in("env", "prod"), in("team", "a")) == nil)
+}
+
+func Test_webhook_mergeSelectors(t *testing.T) {
+	wh := newWebhook(kindValidating, DefaultWebhookTimeout, kyverno.Fail)
+	wh.mergeSelectors(webhookSelectors{namespaceSelector: namespacesSelector([]string{"prod"})})
+	wh.mergeSelectors(webhookSelectors{namespaceSelector: namespacesSelector([]string{"dev"})})
+	assert.DeepEqual(t, wh.namespaceSelector, namespacesSelector([]string{"dev", "prod"}))
+	assert.Assert(t, wh.objectSelector == nil)
+
+	wh.mergeSelectors(webhookSelectors{})
+	assert.Assert(t, wh.namespaceSelector == nil)
+}
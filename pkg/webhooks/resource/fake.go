@@ -20,7 +20,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 )
 
-func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhooks.Handlers {
+func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhooks.ResourceHandlers {
 	client := fake.NewSimpleClientset()
 	metricsConfig := metrics.NewFakeMetricsConfig(client)
 
@@ -51,6 +51,7 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 		auditHandler:      newFakeAuditHandler(),
 		openAPIController: openapi.NewFake(),
 		pcBuilder:         webhookutils.NewPolicyContextBuilder(configuration, dclient, rbLister, crbLister),
+		dedicatedWebhooks: true,
 	}
 }
 
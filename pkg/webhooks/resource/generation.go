@@ -17,9 +17,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	enginutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/event"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -120,23 +122,104 @@ func (h *handlers) handleUpdateGenerateSourceResource(resLabels map[string]strin
 				logger.Error(err, "failed to get generate policy", "Name", policyName)
 			}
 		} else {
-			selector := labels.SelectorFromSet(labels.Set(map[string]string{
-				kyvernov1beta1.URGeneratePolicyLabel: policyName,
-			}))
+			h.updateURsOfPolicy(policyName, logger)
+		}
+	}
+}
 
-			urList, err := h.urLister.List(selector)
+// handleUpdatesForCloneListSources - handles the source resources of the generate rules clone lists, the update
+// requests of a synchronized policy are updated when one of its sources is created, deleted, labeled or unlabeled.
+// The other updates of a source are handled through its clone-policy-name label.
+func (h *handlers) handleUpdatesForCloneListSources(logger logr.Logger, request *admissionv1.AdmissionRequest, policies []kyvernov1.PolicyInterface) {
+	var oldResource, newResource *unstructured.Unstructured
+	if len(request.OldObject.Raw) != 0 {
+		resource, err := enginutils.ConvertToUnstructured(request.OldObject.Raw)
+		if err != nil {
+			logger.Error(err, "failed to convert object resource to unstructured format")
+			return
+		}
+		oldResource = resource
+	}
+	if len(request.Object.Raw) != 0 {
+		resource, err := enginutils.ConvertToUnstructured(request.Object.Raw)
+		if err != nil {
+			logger.Error(err, "failed to convert object resource to unstructured format")
+			return
+		}
+		newResource = resource
+	}
+
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			if !rule.Generation.Synchronize || rule.Generation.CloneList.IsEmpty() {
+				continue
+			}
+			// the namespace of the sources depends on the trigger of each update request
+			if variables.IsVariable(rule.Generation.CloneList.Namespace) {
+				h.updateURsOfCloneList(policy.GetName(), rule.Generation.CloneList, request, oldResource, newResource, logger)
+				continue
+			}
+			changed, err := cloneListSourceChanged(rule.Generation.CloneList, request.Kind.Kind, request.Namespace, oldResource, newResource)
 			if err != nil {
-				logger.Error(err, "failed to get update request for the resource", "label", kyvernov1beta1.URGeneratePolicyLabel)
-				return
+				logger.Error(err, "failed to match the clone list source", "policy", policy.GetName(), "rule", rule.Name)
+				continue
 			}
-
-			for _, ur := range urList {
-				h.updateAnnotationInUR(ur, logger)
+			if changed {
+				h.updateURsOfPolicy(policy.GetName(), logger)
+				break
 			}
 		}
 	}
 }
 
+// updateURsOfCloneList - updates the update requests of the generate policy for which the clone list, with its
+// namespace substituted against their trigger, selects the source before or after the request but not both
+func (h *handlers) updateURsOfCloneList(policyName string, cloneList kyvernov1.CloneList, request *admissionv1.AdmissionRequest, oldResource, newResource *unstructured.Unstructured, logger logr.Logger) {
+	selector := labels.SelectorFromSet(labels.Set(map[string]string{
+		kyvernov1beta1.URGeneratePolicyLabel: policyName,
+	}))
+
+	urList, err := h.urLister.List(selector)
+	if err != nil {
+		logger.Error(err, "failed to get update request for the resource", "label", kyvernov1beta1.URGeneratePolicyLabel)
+		return
+	}
+
+	for _, ur := range urList {
+		urCloneList := cloneList
+		urCloneList.Namespace, err = cloneListNamespace(h.client, cloneList.Namespace, ur, logger)
+		if err != nil {
+			logger.Error(err, "failed to substitute the clone list namespace", "update request", ur.Name)
+			continue
+		}
+		changed, err := cloneListSourceChanged(urCloneList, request.Kind.Kind, request.Namespace, oldResource, newResource)
+		if err != nil {
+			logger.Error(err, "failed to match the clone list source", "update request", ur.Name)
+			continue
+		}
+		if changed {
+			h.updateAnnotationInUR(ur, logger)
+		}
+	}
+}
+
+// updateURsOfPolicy - updates the update requests of the generate policy to reprocess them
+func (h *handlers) updateURsOfPolicy(policyName string, logger logr.Logger) {
+	selector := labels.SelectorFromSet(labels.Set(map[string]string{
+		kyvernov1beta1.URGeneratePolicyLabel: policyName,
+	}))
+
+	urList, err := h.urLister.List(selector)
+	if err != nil {
+		logger.Error(err, "failed to get update request for the resource", "label", kyvernov1beta1.URGeneratePolicyLabel)
+		return
+	}
+
+	for _, ur := range urList {
+		h.updateAnnotationInUR(ur, logger)
+	}
+}
+
 // updateAnnotationInUR - function used to update UR annotation
 // updating UR will trigger reprocessing of UR and recreation/updation of generated resource
 func (h *handlers) updateAnnotationInUR(ur *kyvernov1beta1.UpdateRequest, logger logr.Logger) {
@@ -205,6 +288,27 @@ func (h *handlers) handleUpdateGenerateTargetResource(request *admissionv1.Admis
 				}
 			}
 		}
+
+		// the resources generated for the elements of a foreach are restored by re-processing the update request
+		if generatedByForEach(rule, resLabels) {
+			enqueueBool = true
+			break
+		}
+
+		cloneList := rule.Generation.CloneList
+		if rule.Generation.Synchronize && containsKind(cloneList.Kinds, targetSourceKind) {
+			obj, err := h.client.GetResource("", targetSourceKind, cloneList.Namespace, targetSourceName)
+			if err != nil {
+				logger.V(4).Info("skipping generate policy and resource pattern validaton", "error", err)
+				continue
+			}
+
+			sourceObj, newResObj := stripNonPolicyFields(obj.Object, newRes.Object, logger)
+			if _, err := gen.ValidateResourceWithPattern(logger, newResObj, sourceObj); err != nil {
+				enqueueBool = true
+				break
+			}
+		}
 	}
 
 	if enqueueBool {
@@ -5,7 +5,15 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_updateFeildsInSourceAndUpdatedResource(t *testing.T) {
@@ -273,3 +281,92 @@ func Test_updateFeildsInSourceAndUpdatedResource(t *testing.T) {
 	}
 
 }
+
+func Test_cloneListSelects(t *testing.T) {
+	cloneList := kyvernov1.CloneList{
+		Namespace: "default",
+		Kinds:     []string{"v1/ConfigMap"},
+		Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"allowedToBeCloned": "true"}},
+	}
+	labeled := &unstructured.Unstructured{}
+	labeled.SetLabels(map[string]string{"allowedToBeCloned": "true"})
+	unlabeled := &unstructured.Unstructured{}
+
+	testcases := []struct {
+		name      string
+		kind      string
+		namespace string
+		resource  *unstructured.Unstructured
+		expected  bool
+	}{
+		{name: "labeled source", kind: "ConfigMap", namespace: "default", resource: labeled, expected: true},
+		{name: "unlabeled source", kind: "ConfigMap", namespace: "default", resource: unlabeled, expected: false},
+		{name: "no resource", kind: "ConfigMap", namespace: "default", resource: nil, expected: false},
+		{name: "other namespace", kind: "ConfigMap", namespace: "prod", resource: labeled, expected: false},
+		{name: "other kind", kind: "Secret", namespace: "default", resource: labeled, expected: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := cloneListSelects(cloneList, tc.kind, tc.namespace, tc.resource)
+			assert.NilError(t, err)
+			assert.Equal(t, selected, tc.expected)
+		})
+	}
+}
+
+func Test_cloneListNamespace(t *testing.T) {
+	trigger := &unstructured.Unstructured{}
+	trigger.SetAPIVersion("v1")
+	trigger.SetKind("Namespace")
+	trigger.SetName("team-a")
+	rawTrigger, err := trigger.MarshalJSON()
+	assert.NilError(t, err)
+
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+	}, trigger)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	admissionUR := &kyvernov1beta1.UpdateRequest{}
+	admissionUR.Spec.Context.AdmissionRequestInfo.AdmissionRequest = &admissionv1.AdmissionRequest{Operation: admissionv1.Create}
+	admissionUR.Spec.Context.AdmissionRequestInfo.AdmissionRequest.Object.Raw = rawTrigger
+	namespace, err := cloneListNamespace(client, "{{request.object.metadata.name}}-sources", admissionUR, logr.Discard())
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "team-a-sources")
+
+	backgroundUR := &kyvernov1beta1.UpdateRequest{}
+	backgroundUR.Spec.Resource = kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "team-a"}
+	namespace, err = cloneListNamespace(client, "{{request.object.metadata.name}}-sources", backgroundUR, logr.Discard())
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "team-a-sources")
+
+	missingUR := &kyvernov1beta1.UpdateRequest{}
+	missingUR.Spec.Resource = kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "team-b"}
+	_, err = cloneListNamespace(client, "{{request.object.metadata.name}}-sources", missingUR, logr.Discard())
+	assert.Assert(t, err != nil)
+}
+
+func Test_generatedByForEach(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Name: "generate-per-port",
+		Generation: kyvernov1.Generation{
+			Synchronize:       true,
+			ForEachGeneration: []kyvernov1.ForEachGeneration{{List: "request.object.spec.ports"}},
+		},
+	}
+	resLabels := map[string]string{
+		"generate.kyverno.io/foreach-rule-name": "generate-per-port",
+		"policy.kyverno.io/gr-name":             "ur-1",
+	}
+	assert.Assert(t, generatedByForEach(rule, resLabels))
+	assert.Assert(t, !generatedByForEach(rule, map[string]string{"generate.kyverno.io/foreach-rule-name": "other-rule"}))
+
+	notSynchronized := *rule.DeepCopy()
+	notSynchronized.Generation.Synchronize = false
+	assert.Assert(t, !generatedByForEach(notSynchronized, resLabels))
+
+	withoutForEach := *rule.DeepCopy()
+	withoutForEach.Generation.ForEachGeneration = nil
+	assert.Assert(t, !generatedByForEach(withoutForEach, resLabels))
+}
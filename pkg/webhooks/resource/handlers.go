@@ -61,6 +61,9 @@ type handlers struct {
 	auditHandler      AuditHandler
 	openAPIController openapi.ValidateInterface
 	pcBuilder         webhookutils.PolicyContextBuilder
+
+	// dedicatedWebhooks is set when the policies using a dedicated webhook are registered in their own webhooks
+	dedicatedWebhooks bool
 }
 
 func NewHandlers(
@@ -78,7 +81,8 @@ func NewHandlers(
 	eventGen event.Interface,
 	auditHandler AuditHandler,
 	openAPIController openapi.ValidateInterface,
-) webhooks.Handlers {
+	dedicatedWebhooks bool,
+) webhooks.ResourceHandlers {
 	return &handlers{
 		client:            client,
 		kyvernoClient:     kyvernoClient,
@@ -95,6 +99,7 @@ func NewHandlers(
 		auditHandler:      auditHandler,
 		openAPIController: openAPIController,
 		pcBuilder:         webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister),
+		dedicatedWebhooks: dedicatedWebhooks,
 	}
 }
 
@@ -111,10 +116,10 @@ func (h *handlers) Validate(logger logr.Logger, request *admissionv1.AdmissionRe
 
 	// timestamp at which this admission request got triggered
 	requestTime := time.Now()
-	policies := h.pCache.GetPolicies(policycache.ValidateEnforce, kind, request.Namespace)
+	policies := h.filterPolicies(h.pCache.GetPolicies(policycache.ValidateEnforce, kind, request.Namespace), "")
 	mutatePolicies := h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)
 	generatePolicies := h.pCache.GetPolicies(policycache.Generate, kind, request.Namespace)
-	imageVerifyValidatePolicies := h.pCache.GetPolicies(policycache.VerifyImagesValidate, kind, request.Namespace)
+	imageVerifyValidatePolicies := h.filterPolicies(h.pCache.GetPolicies(policycache.VerifyImagesValidate, kind, request.Namespace), "")
 	policies = append(policies, imageVerifyValidatePolicies...)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 {
@@ -124,6 +129,10 @@ func (h *handlers) Validate(logger logr.Logger, request *admissionv1.AdmissionRe
 		// handle generate source resource updates
 		go h.handleUpdatesForGenerateRules(logger, request, []kyvernov1.PolicyInterface{})
 	}
+	if cloneSourcePolicies := h.pCache.GetPolicies(policycache.GenerateCloneSource, kind, request.Namespace); len(cloneSourcePolicies) != 0 {
+		// handle generate clone list source resources
+		go h.handleUpdatesForCloneListSources(logger, request, cloneSourcePolicies)
+	}
 
 	logger.V(4).Info("processing policies for validate admission request", "validate", len(policies), "mutate", len(mutatePolicies), "generate", len(generatePolicies))
 
@@ -132,18 +141,7 @@ func (h *handlers) Validate(logger logr.Logger, request *admissionv1.AdmissionRe
 		return errorResponse(logger, err, "failed create policy context")
 	}
 
-	namespaceLabels := make(map[string]string)
-	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		namespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
-	}
-
-	vh := &validationHandler{
-		log:         logger,
-		eventGen:    h.eventGen,
-		prGenerator: h.prGenerator,
-	}
-
-	ok, msg, warnings := vh.handleValidation(h.metricsConfig, request, policies, policyContext, namespaceLabels, requestTime)
+	ok, msg, warnings := h.validateResource(logger, request, policies, policyContext, requestTime)
 	if !ok {
 		logger.Info("admission request denied")
 		return admissionutils.ResponseFailure(msg)
@@ -160,11 +158,75 @@ func (h *handlers) Validate(logger logr.Logger, request *admissionv1.AdmissionRe
 	return admissionutils.ResponseSuccess()
 }
 
+// ValidatePolicy only performs the validation checks of the policy, the requests are
+// also sent to the shared webhook which processes them in the background
+func (h *handlers) ValidatePolicy(logger logr.Logger, request *admissionv1.AdmissionRequest, policyKey string) *admissionv1.AdmissionResponse {
+	if excludeKyvernoResources(request.Kind.Kind) {
+		return admissionutils.ResponseSuccess()
+	}
+	kind := request.Kind.Kind
+	logger = logger.WithValues("kind", kind)
+	logger.V(4).Info("received an admission request in dedicated validating webhook")
+
+	requestTime := time.Now()
+	policies := h.filterPolicies(h.pCache.GetPolicies(policycache.ValidateEnforce, kind, request.Namespace), policyKey)
+	imageVerifyValidatePolicies := h.filterPolicies(h.pCache.GetPolicies(policycache.VerifyImagesValidate, kind, request.Namespace), policyKey)
+	policies = append(policies, imageVerifyValidatePolicies...)
+	if len(policies) == 0 {
+		logger.V(4).Info("no policies matched admission request")
+		return admissionutils.ResponseSuccess()
+	}
+
+	policyContext, err := h.pcBuilder.Build(request, policies...)
+	if err != nil {
+		return errorResponse(logger, err, "failed create policy context")
+	}
+
+	ok, msg, warnings := h.validateResource(logger, request, policies, policyContext, requestTime)
+	if !ok {
+		logger.Info("admission request denied")
+		return admissionutils.ResponseFailure(msg)
+	}
+	if warnings != nil {
+		return admissionutils.ResponseSuccessWithWarnings(warnings)
+	}
+
+	logger.V(4).Info("completed dedicated validating webhook")
+	return admissionutils.ResponseSuccess()
+}
+
+func (h *handlers) validateResource(logger logr.Logger, request *admissionv1.AdmissionRequest, policies []kyvernov1.PolicyInterface, policyContext *engine.PolicyContext, requestTime time.Time) (bool, string, []string) {
+	namespaceLabels := make(map[string]string)
+	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
+		namespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
+	}
+
+	vh := &validationHandler{
+		log:         logger,
+		eventGen:    h.eventGen,
+		prGenerator: h.prGenerator,
+	}
+
+	return vh.handleValidation(h.metricsConfig, request, policies, policyContext, namespaceLabels, requestTime)
+}
+
 func (h *handlers) Mutate(logger logr.Logger, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	return h.mutate(logger, request, "")
+}
+
+func (h *handlers) MutatePolicy(logger logr.Logger, request *admissionv1.AdmissionRequest, policyKey string) *admissionv1.AdmissionResponse {
+	return h.mutate(logger, request, policyKey)
+}
+
+// mutate applies the policies of the webhook identified by the policy key, empty for the shared webhook
+func (h *handlers) mutate(logger logr.Logger, request *admissionv1.AdmissionRequest, policyKey string) *admissionv1.AdmissionResponse {
 	if excludeKyvernoResources(request.Kind.Kind) {
 		return admissionutils.ResponseSuccess()
 	}
 	if request.Operation == admissionv1.Delete {
+		if policyKey != "" {
+			return admissionutils.ResponseSuccess()
+		}
 		resource, err := utils.ConvertResource(request.OldObject.Raw, request.Kind.Group, request.Kind.Version, request.Kind.Kind, request.Namespace)
 		if err == nil {
 			h.prGenerator.Add(buildDeletionPrInfo(resource))
@@ -177,8 +239,8 @@ func (h *handlers) Mutate(logger logr.Logger, request *admissionv1.AdmissionRequ
 	logger = logger.WithValues("kind", kind)
 	logger.V(4).Info("received an admission request in mutating webhook")
 	requestTime := time.Now()
-	mutatePolicies := h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace)
-	verifyImagesPolicies := h.pCache.GetPolicies(policycache.VerifyImagesMutate, kind, request.Namespace)
+	mutatePolicies := h.filterPolicies(h.pCache.GetPolicies(policycache.Mutate, kind, request.Namespace), policyKey)
+	verifyImagesPolicies := h.filterPolicies(h.pCache.GetPolicies(policycache.VerifyImagesMutate, kind, request.Namespace), policyKey)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
 		return admissionutils.ResponseSuccess()
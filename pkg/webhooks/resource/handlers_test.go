@@ -232,6 +232,46 @@ func Test_AdmissionResponseInvalid(t *testing.T) {
 	assert.Equal(t, len(response.Warnings), 1)
 }
 
+func Test_AdmissionResponseDedicatedWebhook(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.Log.WithName("Test_AdmissionResponseDedicatedWebhook")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlers := NewFakeHandlers(ctx, policyCache)
+
+	var validPolicy kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policyCheckLabel), &validPolicy)
+	assert.NilError(t, err)
+
+	validPolicy.Spec.ValidationFailureAction = kyverno.Enforce
+	validPolicy.Spec.UseDedicatedWebhook = true
+	key := makeKey(&validPolicy)
+	policyCache.Set(key, &validPolicy)
+
+	request := &v1.AdmissionRequest{
+		Operation: v1.Create,
+		Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+		Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "Pod"},
+		Object: runtime.RawExtension{
+			Raw: []byte(pod),
+		},
+	}
+
+	// the policy is only evaluated by its own webhook
+	response := handlers.Validate(logger, request)
+	assert.Equal(t, response.Allowed, true)
+
+	response = handlers.ValidatePolicy(logger, request, "check-label-app")
+	assert.Equal(t, response.Allowed, false)
+
+	response = handlers.ValidatePolicy(logger, request, "other-policy")
+	assert.Equal(t, response.Allowed, true)
+
+	policyCache.Unset(key)
+}
+
 func Test_ImageVerify(t *testing.T) {
 	policyCache := policycache.NewCache()
 	logger := log.Log.WithName("Test_ImageVerify")
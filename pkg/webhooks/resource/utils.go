@@ -17,10 +17,13 @@ import (
 	"github.com/kyverno/kyverno/pkg/policyreport"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils2 "github.com/kyverno/kyverno/pkg/utils/engine"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	yamlv2 "gopkg.in/yaml.v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type updateRequestResponse struct {
@@ -45,6 +48,32 @@ func excludeKyvernoResources(kind string) bool {
 	}
 }
 
+// filterPolicies returns the policies evaluated by the webhook identified by the policy key, empty for
+// the shared webhook. The policies using a dedicated webhook are only evaluated by their own webhook.
+func (h *handlers) filterPolicies(policies []kyvernov1.PolicyInterface, policyKey string) []kyvernov1.PolicyInterface {
+	if !h.dedicatedWebhooks {
+		return policies
+	}
+	var filtered []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		if policyKey == "" {
+			if !policy.GetSpec().UseDedicatedWebhook {
+				filtered = append(filtered, policy)
+			}
+		} else if getPolicyKey(policy) == policyKey {
+			filtered = append(filtered, policy)
+		}
+	}
+	return filtered
+}
+
+func getPolicyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() == "" {
+		return policy.GetName()
+	}
+	return policy.GetNamespace() + "/" + policy.GetName()
+}
+
 func errorResponse(logger logr.Logger, err error, message string) *admissionv1.AdmissionResponse {
 	logger.Error(err, message)
 	return admissionutils.ResponseFailure(message + ": " + err.Error())
@@ -216,6 +245,88 @@ func getGeneratedByResource(newRes *unstructured.Unstructured, resLabels map[str
 	return rule, nil
 }
 
+// containsKind checks if the kind is found in the list of kinds, the kinds can include the group and the version
+func containsKind(kinds []string, kind string) bool {
+	for _, gvk := range kinds {
+		if _, k := kubeutils.GetKindFromGVK(gvk); k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedByForEach checks if the resource with the labels is generated for an element of the foreach of the
+// synchronized rule
+func generatedByForEach(rule kyvernov1.Rule, resLabels map[string]string) bool {
+	return rule.Generation.Synchronize && len(rule.Generation.ForEachGeneration) != 0 && resLabels["generate.kyverno.io/foreach-rule-name"] == rule.Name
+}
+
+// cloneListSelects checks if the resource of the kind and namespace is a source of the clone list,
+// a nil resource is never selected
+func cloneListSelects(cloneList kyvernov1.CloneList, kind, namespace string, resource *unstructured.Unstructured) (bool, error) {
+	if resource == nil || cloneList.Namespace != namespace || !containsKind(cloneList.Kinds, kind) {
+		return false, nil
+	}
+	if cloneList.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cloneList.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(resource.GetLabels())), nil
+}
+
+// cloneListSourceChanged checks if the resource of the kind and namespace is selected by the clone list either
+// before or after the request, i.e. if it is created, deleted, labeled or unlabeled as a source
+func cloneListSourceChanged(cloneList kyvernov1.CloneList, kind, namespace string, oldResource, newResource *unstructured.Unstructured) (bool, error) {
+	oldMatch, err := cloneListSelects(cloneList, kind, namespace, oldResource)
+	if err != nil {
+		return false, err
+	}
+	newMatch, err := cloneListSelects(cloneList, kind, namespace, newResource)
+	if err != nil {
+		return false, err
+	}
+	return oldMatch != newMatch, nil
+}
+
+// cloneListNamespace substitutes the variables of the clone list namespace against the trigger of the update request,
+// the trigger is fetched when the update request was not created for an admission request
+func cloneListNamespace(client dclient.Interface, namespace string, ur *kyvernov1beta1.UpdateRequest, logger logr.Logger) (string, error) {
+	request := ur.Spec.Context.AdmissionRequestInfo.AdmissionRequest
+	if request == nil {
+		trigger := ur.Spec.Resource
+		obj, err := client.GetResource(trigger.APIVersion, trigger.Kind, trigger.Namespace, trigger.Name)
+		if err != nil {
+			return "", err
+		}
+		rawObj, err := json.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		request = &admissionv1.AdmissionRequest{Operation: admissionv1.Create}
+		request.Object.Raw = rawObj
+	}
+
+	ctx := enginectx.NewContext()
+	if err := ctx.AddRequest(request); err != nil {
+		return "", err
+	}
+	if err := ctx.AddUserInfo(ur.Spec.Context.UserRequestInfo); err != nil {
+		return "", err
+	}
+	substituted, err := variables.SubstituteAll(logger, ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	ns, ok := substituted.(string)
+	if !ok {
+		return "", fmt.Errorf("the clone list namespace %s is not substituted with a string", namespace)
+	}
+	return ns, nil
+}
+
 // stripNonPolicyFields - remove feilds which get updated with each request by kyverno and are non policy fields
 func stripNonPolicyFields(obj, newRes map[string]interface{}, logger logr.Logger) (map[string]interface{}, map[string]interface{}) {
 	if metadata, found := obj["metadata"]; found {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,6 +30,14 @@ type Handlers interface {
 	Validate(logr.Logger, *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
 }
 
+type ResourceHandlers interface {
+	Handlers
+	// MutatePolicy performs the mutation of resources with a policy registered in a dedicated webhook
+	MutatePolicy(logr.Logger, *admissionv1.AdmissionRequest, string) *admissionv1.AdmissionResponse
+	// ValidatePolicy performs the validation check on resources with a policy registered in a dedicated webhook
+	ValidatePolicy(logr.Logger, *admissionv1.AdmissionRequest, string) *admissionv1.AdmissionResponse
+}
+
 type server struct {
 	server          *http.Server
 	webhookRegister *webhookconfig.Register
@@ -40,7 +49,7 @@ type TlsProvider func() ([]byte, []byte, error)
 // NewServer creates new instance of server accordingly to given configuration
 func NewServer(
 	policyHandlers Handlers,
-	resourceHandlers Handlers,
+	resourceHandlers ResourceHandlers,
 	tlsProvider TlsProvider,
 	configuration config.Configuration,
 	register *webhookconfig.Register,
@@ -53,6 +62,8 @@ func NewServer(
 	verifyLogger := logger.WithName("verify")
 	mux.HandlerFunc("POST", config.MutatingWebhookServicePath, admission(resourceLogger.WithName("mutate"), monitor, filter(configuration, resourceHandlers.Mutate)))
 	mux.HandlerFunc("POST", config.ValidatingWebhookServicePath, admission(resourceLogger.WithName("validate"), monitor, filter(configuration, resourceHandlers.Validate)))
+	mux.POST(config.DedicatedMutatingWebhookServicePath+"/*policy", dedicated(resourceLogger.WithName("mutate"), monitor, configuration, resourceHandlers.MutatePolicy))
+	mux.POST(config.DedicatedValidatingWebhookServicePath+"/*policy", dedicated(resourceLogger.WithName("validate"), monitor, configuration, resourceHandlers.ValidatePolicy))
 	mux.HandlerFunc("POST", config.PolicyMutatingWebhookServicePath, admission(policyLogger.WithName("mutate"), monitor, filter(configuration, policyHandlers.Mutate)))
 	mux.HandlerFunc("POST", config.PolicyValidatingWebhookServicePath, admission(policyLogger.WithName("validate"), monitor, filter(configuration, policyHandlers.Validate)))
 	mux.HandlerFunc("POST", config.VerifyMutatingWebhookServicePath, admission(verifyLogger.WithName("mutate"), monitor, handlers.Verify(monitor)))
@@ -125,3 +136,14 @@ func filter(configuration config.Configuration, inner handlers.AdmissionHandler)
 func admission(logger logr.Logger, monitor *webhookconfig.Monitor, inner handlers.AdmissionHandler) http.HandlerFunc {
 	return handlers.Monitor(monitor, handlers.Admission(logger, inner))
 }
+
+// dedicated serves the admission requests of a webhook dedicated to the policy whose key is given in the path
+func dedicated(logger logr.Logger, monitor *webhookconfig.Monitor, configuration config.Configuration, inner func(logr.Logger, *admissionv1.AdmissionRequest, string) *admissionv1.AdmissionResponse) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		policyKey := strings.TrimPrefix(params.ByName("policy"), "/")
+		handler := func(logger logr.Logger, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+			return inner(logger.WithValues("policy", policyKey), request, policyKey)
+		}
+		admission(logger, monitor, filter(configuration, handler))(w, r)
+	}
+}